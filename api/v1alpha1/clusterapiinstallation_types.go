@@ -0,0 +1,100 @@
+// Package v1alpha1 contains the operator's own CRDs, as distinct from the
+// upstream Cluster API and OpenShift config APIs it consumes.
+package v1alpha1
+
+import (
+	configv1 "github.com/openshift/api/config/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterAPIInstallationSpec declares which Cluster API components the
+// operator should install.
+type ClusterAPIInstallationSpec struct {
+	// Platform selects which infrastructure provider to install. It must
+	// match the cluster's detected PlatformType unless ForcePlatform is
+	// set on the operator.
+	Platform configv1.PlatformType `json:"platform"`
+
+	// CoreOnly installs only the Cluster API core components and the
+	// kubeconfig secret, omitting the infrastructure provider. Used with
+	// PlatformType None to bring a user-supplied infrastructure provider.
+	// +optional
+	CoreOnly bool `json:"coreOnly,omitempty"`
+
+	// Version pins the provider version to install. Mutually exclusive
+	// with Channel; when both are empty, the operator installs its
+	// default bundled version.
+	// +optional
+	Version string `json:"version,omitempty"`
+
+	// Channel selects a release channel ("stable", "fast", "candidate")
+	// that the operator resolves to a concrete provider version, tracking
+	// new releases within that channel automatically.
+	// +optional
+	// +kubebuilder:validation:Enum=stable;fast;candidate
+	Channel string `json:"channel,omitempty"`
+
+	// LogLevel sets the klog verbosity (-v) passed to provider containers.
+	// Changing it patches the provider Deployments' container args
+	// directly, so the resulting rollout is driven by the Deployment
+	// controller rather than by the operator re-running a full install.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=10
+	LogLevel int32 `json:"logLevel,omitempty"`
+
+	// ImageOverrides maps a container name to an image reference to use
+	// instead of the operator's default, for every operator-managed
+	// provider Deployment that has a container with that name. Like
+	// LogLevel, changing it patches the provider Deployments' container
+	// images directly and takes effect without an operator restart.
+	// +optional
+	ImageOverrides map[string]string `json:"imageOverrides,omitempty"`
+
+	// InClusterAuth skips publishing the management-cluster kubeconfig
+	// secret (see pkg/controllers/kubeconfig) and instead mounts a
+	// projected ServiceAccount token volume on every operator-managed
+	// provider Deployment (see pkg/controllers/projectedtoken), so a
+	// provider running on the management cluster it manages authenticates
+	// via its Pod identity rather than an embedded, long-lived token.
+	// Leave unset for providers that need a kubeconfig because they run
+	// outside the cluster they authenticate to.
+	// +optional
+	InClusterAuth bool `json:"inClusterAuth,omitempty"`
+}
+
+// ClusterAPIInstallationStatus reports the observed state of the
+// installation.
+type ClusterAPIInstallationStatus struct {
+	// Conditions represent the latest available observations of the
+	// installation's state.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// InstalledVersion is the provider version currently installed.
+	// +optional
+	InstalledVersion string `json:"installedVersion,omitempty"`
+
+	// FIPSEnabled reports whether the operator detected FIPS mode on its
+	// host and is generating certificates and keys using FIPS-approved
+	// algorithms and key sizes accordingly.
+	// +optional
+	FIPSEnabled bool `json:"fipsEnabled,omitempty"`
+}
+
+// ClusterAPIInstallation is the Schema for declaratively enabling Cluster
+// API support on a platform.
+type ClusterAPIInstallation struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterAPIInstallationSpec   `json:"spec,omitempty"`
+	Status ClusterAPIInstallationStatus `json:"status,omitempty"`
+}
+
+// ClusterAPIInstallationList contains a list of ClusterAPIInstallation.
+type ClusterAPIInstallationList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterAPIInstallation `json:"items"`
+}
@@ -0,0 +1,72 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterSummary reports what the operator knows about one CAPI Cluster,
+// for admins who want a single place to see everything this hub
+// manages instead of listing Cluster objects across every namespace.
+type ClusterSummary struct {
+	// Name is the Cluster's name.
+	Name string `json:"name"`
+
+	// Namespace is the Cluster's namespace.
+	Namespace string `json:"namespace"`
+
+	// Platform is the infrastructure provider backing the Cluster,
+	// taken from its infrastructure reference's Kind.
+	// +optional
+	Platform string `json:"platform,omitempty"`
+
+	// KubernetesVersion is the workload cluster's control plane version,
+	// when known.
+	// +optional
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	// Phase is the Cluster's current lifecycle phase, as reported on the
+	// Cluster object itself.
+	// +optional
+	Phase string `json:"phase,omitempty"`
+
+	// KubeconfigSecretRef names the Secret holding the workload
+	// cluster's kubeconfig, when one has been published for it.
+	// +optional
+	KubeconfigSecretRef string `json:"kubeconfigSecretRef,omitempty"`
+}
+
+// ClusterInventorySpec is empty: ClusterInventory has no user-facing
+// configuration, it only reports observed state.
+type ClusterInventorySpec struct{}
+
+// ClusterInventoryStatus reports every CAPI Cluster currently known to
+// the operator.
+type ClusterInventoryStatus struct {
+	// Clusters lists every CAPI Cluster found across the watched
+	// namespace(s), sorted by namespace then name.
+	// +optional
+	Clusters []ClusterSummary `json:"clusters,omitempty"`
+
+	// LastUpdated is when this status was last recomputed.
+	// +optional
+	LastUpdated *metav1.Time `json:"lastUpdated,omitempty"`
+}
+
+// ClusterInventory is the Schema for a cluster-scoped singleton
+// summarizing every CAPI Cluster the operator manages, so admins don't
+// need to list Cluster objects across every namespace to see what's on
+// a hub.
+type ClusterInventory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterInventorySpec   `json:"spec,omitempty"`
+	Status ClusterInventoryStatus `json:"status,omitempty"`
+}
+
+// ClusterInventoryList contains a list of ClusterInventory.
+type ClusterInventoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterInventory `json:"items"`
+}
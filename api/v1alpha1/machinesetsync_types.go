@@ -0,0 +1,56 @@
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// MachineSetSyncSpec identifies the MAPI MachineSet this status object
+// tracks the conversion of.
+type MachineSetSyncSpec struct {
+	// MAPIMachineSetName is the name of the source machine-api MachineSet.
+	MAPIMachineSetName string `json:"mapiMachineSetName"`
+}
+
+// MachineSetSyncStatus reports the outcome of converting a MAPI MachineSet
+// to its CAPI equivalent.
+type MachineSetSyncStatus struct {
+	// Conditions include a "Synchronized" condition reporting whether the
+	// last conversion attempt succeeded.
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// CAPIMachineSetName is the name of the resulting CAPI MachineSet,
+	// once conversion has succeeded at least once.
+	// +optional
+	CAPIMachineSetName string `json:"capiMachineSetName,omitempty"`
+
+	// LastSyncTime is when the conversion was last attempted.
+	// +optional
+	LastSyncTime *metav1.Time `json:"lastSyncTime,omitempty"`
+
+	// UnsupportedFields lists the provider-specific fields the last
+	// conversion attempt could not represent on the destination API and
+	// therefore refused to drop silently, each as a short human-readable
+	// description (e.g. "Placement.Tenancy: \"unknown\""). Empty when the
+	// last attempt fully converted the MachineSet.
+	// +optional
+	UnsupportedFields []string `json:"unsupportedFields,omitempty"`
+}
+
+// MachineSetSync is the Schema for reporting MAPI-to-CAPI MachineSet
+// conversion results as a first-class, listable resource rather than only
+// as log lines or events.
+type MachineSetSync struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   MachineSetSyncSpec   `json:"spec,omitempty"`
+	Status MachineSetSyncStatus `json:"status,omitempty"`
+}
+
+// MachineSetSyncList contains a list of MachineSetSync.
+type MachineSetSyncList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []MachineSetSync `json:"items"`
+}
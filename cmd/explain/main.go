@@ -0,0 +1,55 @@
+// Command explain reads the cluster-api ClusterOperator's conditions and
+// recent warning Events from a live cluster and prints a human-readable
+// root-cause analysis, so diagnosing why the operator is degraded doesn't
+// require reconstructing it by hand from raw API objects.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/cluster-capi-operator/pkg/diagnose"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(configv1.AddToScheme(scheme))
+}
+
+func main() {
+	var namespace string
+
+	flag.StringVar(&namespace, "namespace", "openshift-cluster-api", "The namespace the operator manages resources in.")
+	flag.Parse()
+
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "unable to load kubeconfig:", err)
+		os.Exit(1)
+	}
+
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "unable to create client:", err)
+		os.Exit(1)
+	}
+
+	report, err := diagnose.Explain(context.Background(), c, namespace)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	fmt.Print(report)
+}
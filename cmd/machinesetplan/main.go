@@ -0,0 +1,85 @@
+// Command machinesetplan reports the blast radius of a proposed
+// InfrastructureRef change to a live CAPI MachineSet -- how many of its
+// existing Machines would be rolled and which fields force the
+// replacement -- so admins can plan a CAPI-based scale/rollout action
+// before applying it.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/cluster-capi-operator/pkg/plan"
+)
+
+var scheme = runtime.NewScheme()
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(clusterv1.AddToScheme(scheme))
+}
+
+func main() {
+	var namespace, machineSetName, infraRefKind, infraRefAPIVersion, infraRefName string
+
+	flag.StringVar(&namespace, "namespace", "openshift-cluster-api", "The namespace the MachineSet lives in.")
+	flag.StringVar(&machineSetName, "machineset", "", "The name of the MachineSet to plan against.")
+	flag.StringVar(&infraRefKind, "infrastructure-ref-kind", "", "The Kind of the proposed InfrastructureRef, if changing it.")
+	flag.StringVar(&infraRefAPIVersion, "infrastructure-ref-api-version", "", "The APIVersion of the proposed InfrastructureRef, if changing it.")
+	flag.StringVar(&infraRefName, "infrastructure-ref-name", "", "The Name of the proposed InfrastructureRef, if changing it.")
+	flag.Parse()
+
+	if machineSetName == "" {
+		fmt.Fprintln(os.Stderr, "-machineset is required")
+		os.Exit(1)
+	}
+
+	restConfig, err := ctrl.GetConfig()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "unable to load kubeconfig:", err)
+		os.Exit(1)
+	}
+
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "unable to create client:", err)
+		os.Exit(1)
+	}
+
+	ctx := context.Background()
+
+	machineSet := &clusterv1.MachineSet{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: machineSetName}, machineSet); err != nil {
+		fmt.Fprintln(os.Stderr, "unable to get MachineSet:", err)
+		os.Exit(1)
+	}
+
+	desired := *machineSet.Spec.Template.DeepCopy()
+	if infraRefKind != "" || infraRefAPIVersion != "" || infraRefName != "" {
+		desired.Spec.InfrastructureRef = corev1.ObjectReference{
+			Kind:       infraRefKind,
+			APIVersion: infraRefAPIVersion,
+			Name:       infraRefName,
+			Namespace:  namespace,
+		}
+	}
+
+	machineCount, err := plan.CountMachines(ctx, c, namespace, machineSetName)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	rolloutPlan := plan.Plan(machineSet.Spec.Template, desired, machineCount)
+	fmt.Println(rolloutPlan.String())
+}
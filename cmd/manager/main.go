@@ -0,0 +1,473 @@
+// Command manager runs the cluster-capi-operator controllers that install
+// and manage Cluster API core and infrastructure providers on OpenShift.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	clientcmd "k8s.io/client-go/tools/clientcmd"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/healthz"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	machinev1 "github.com/openshift/api/machine/v1"
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+
+	capav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+
+	operatorv1alpha1 "github.com/openshift/cluster-capi-operator/api/v1alpha1"
+	"github.com/openshift/cluster-capi-operator/pkg/cachebudget"
+	"github.com/openshift/cluster-capi-operator/pkg/controllers/adopt"
+	"github.com/openshift/cluster-capi-operator/pkg/controllers/clusterinventory"
+	"github.com/openshift/cluster-capi-operator/pkg/controllers/clusteroperator"
+	"github.com/openshift/cluster-capi-operator/pkg/controllers/controlplane"
+	"github.com/openshift/cluster-capi-operator/pkg/controllers/credentialrotation"
+	"github.com/openshift/cluster-capi-operator/pkg/controllers/faildomain"
+	"github.com/openshift/cluster-capi-operator/pkg/controllers/featuregate"
+	"github.com/openshift/cluster-capi-operator/pkg/controllers/gcjanitor"
+	"github.com/openshift/cluster-capi-operator/pkg/controllers/imageoverride"
+	"github.com/openshift/cluster-capi-operator/pkg/controllers/infratags"
+	"github.com/openshift/cluster-capi-operator/pkg/controllers/kubeconfig"
+	"github.com/openshift/cluster-capi-operator/pkg/controllers/loglevel"
+	"github.com/openshift/cluster-capi-operator/pkg/controllers/machinemetrics"
+	"github.com/openshift/cluster-capi-operator/pkg/controllers/machinesetsync"
+	"github.com/openshift/cluster-capi-operator/pkg/controllers/monitoring"
+	"github.com/openshift/cluster-capi-operator/pkg/controllers/projectedtoken"
+	"github.com/openshift/cluster-capi-operator/pkg/controllers/reencrypt"
+	"github.com/openshift/cluster-capi-operator/pkg/controllers/remotecluster"
+	"github.com/openshift/cluster-capi-operator/pkg/controllers/selfsignedca"
+	"github.com/openshift/cluster-capi-operator/pkg/controllers/stalemachine"
+	"github.com/openshift/cluster-capi-operator/pkg/driftguard"
+	"github.com/openshift/cluster-capi-operator/pkg/fips"
+	"github.com/openshift/cluster-capi-operator/pkg/metrics"
+	"github.com/openshift/cluster-capi-operator/pkg/movereadiness"
+	remoteclusterpool "github.com/openshift/cluster-capi-operator/pkg/remotecluster"
+	"github.com/openshift/cluster-capi-operator/pkg/standby"
+	"github.com/openshift/cluster-capi-operator/pkg/status"
+	"github.com/openshift/cluster-capi-operator/pkg/util/logredact"
+	"github.com/openshift/cluster-capi-operator/pkg/util/namespace"
+	"github.com/openshift/cluster-capi-operator/pkg/util/platform"
+	"github.com/openshift/cluster-capi-operator/pkg/util/satoken"
+	"github.com/openshift/cluster-capi-operator/pkg/webhooks"
+)
+
+var (
+	scheme   = runtime.NewScheme()
+	setupLog = ctrl.Log.WithName("setup")
+)
+
+func init() {
+	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(configv1.AddToScheme(scheme))
+	utilruntime.Must(operatorv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(clusterv1.AddToScheme(scheme))
+	utilruntime.Must(machinev1.AddToScheme(scheme))
+	utilruntime.Must(machinev1beta1.AddToScheme(scheme))
+	utilruntime.Must(capav1.AddToScheme(scheme))
+}
+
+// restConfigFor returns the REST config to run the manager against. When
+// bootstrapKubeconfigPath is set, it is loaded from disk so the operator can
+// run during the bootstrap phase of installation, before the target
+// cluster's in-cluster service account is usable; otherwise the normal
+// in-cluster config is used.
+func restConfigFor(bootstrapKubeconfigPath string) (*rest.Config, error) {
+	if bootstrapKubeconfigPath == "" {
+		return ctrl.GetConfig()
+	}
+	return clientcmd.BuildConfigFromFlags("", bootstrapKubeconfigPath)
+}
+
+func main() {
+	var metricsAddr string
+	var probeAddr string
+	var statusAddr string
+	var managedNamespace string
+	var enableLeaderElection bool
+	var forcePlatform string
+	var kubeconfigConcurrency int
+	var bootstrapKubeconfig string
+	var deploymentMode string
+	var publishCompatKubeconfig bool
+	var enableControlPlaneMachines bool
+	var verifyKubeconfigToken bool
+	var verifyKubeconfigEndpoint bool
+	var enableSelfSignedCA bool
+	var webhookServiceName string
+	var standbyMode bool
+	var enableFleetManagement bool
+	var immutableKubeconfigSecrets bool
+	var forceRegenerateKubeconfigOnAuthFailure bool
+	var gcJanitorDryRun bool
+
+	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metrics endpoint binds to.")
+	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
+	flag.StringVar(&statusAddr, "status-bind-address", ":8082", "The address the JSON status summary endpoint binds to.")
+	flag.StringVar(&managedNamespace, "namespace", "openshift-cluster-api", "The namespace the operator manages resources in.")
+	flag.BoolVar(&enableLeaderElection, "leader-elect", false, "Enable leader election for controller manager.")
+	flag.StringVar(&forcePlatform, "force-platform", "", "Development-only override of the detected infrastructure platform (falls back to CAPI_FORCE_PLATFORM). Marks the ClusterOperator Upgradeable=False while active.")
+	flag.IntVar(&kubeconfigConcurrency, "kubeconfig-concurrency", 1, "Maximum number of kubeconfig secrets to reconcile concurrently.")
+	flag.StringVar(&bootstrapKubeconfig, "bootstrap-kubeconfig", "", "Path to the bootstrap node's kubeconfig. When set, the operator runs against this kubeconfig instead of in-cluster config, for use during the bootstrap phase of installation.")
+	flag.StringVar(&deploymentMode, "deployment-mode", string(platform.DeploymentModeClusterScoped), "Whether the operator watches the whole cluster (ClusterScoped) or is confined to --namespace (NamespaceScoped).")
+	flag.BoolVar(&publishCompatKubeconfig, "publish-compat-kubeconfig-secret", false, "Also publish an Opaque-typed mirror of each kubeconfig secret for consumers that cannot handle the cluster.x-k8s.io/secret type.")
+	flag.BoolVar(&enableControlPlaneMachines, "enable-control-plane-machines", false, "Allow Cluster API to manage control-plane Machines. Until control-plane integration lands, the Machine admission webhook rejects these unless this is set.")
+	flag.BoolVar(&verifyKubeconfigToken, "verify-kubeconfig-token", false, "Perform a SelfSubjectReview using each kubeconfig secret's embedded token before republishing it.")
+	flag.BoolVar(&verifyKubeconfigEndpoint, "verify-kubeconfig-endpoint", false, "Dial each kubeconfig secret's embedded server and validate its certificate against the embedded CA before republishing it.")
+	flag.BoolVar(&enableSelfSignedCA, "enable-self-signed-ca", false, "Maintain a local self-signed CA and webhook serving certificate instead of relying on the service-ca operator, for standalone or development clusters.")
+	flag.StringVar(&webhookServiceName, "webhook-service-name", "cluster-capi-operator-webhook", "Name of the Service fronting the webhook server, used to derive the serving certificate's DNS names.")
+	flag.BoolVar(&standbyMode, "standby", false, "Run fully started but make no writes to the cluster, for a passive management cluster mirroring an active one in a disaster-recovery setup. Promote by sending SIGUSR1.")
+	flag.BoolVar(&enableFleetManagement, "enable-fleet-management", false, "Experimental: maintain a cache-backed client for each remote cluster named by a kubeconfig secret labeled "+remotecluster.FleetMemberLabel+" in --namespace, for managing a fleet of clusters from one operator instance.")
+	flag.BoolVar(&immutableKubeconfigSecrets, "immutable-kubeconfig-secrets", false, "Create kubeconfig secrets as immutable, recreating them on content changes instead of patching in place, to reduce kubelet watch overhead and make rotations an explicit recreate event.")
+	flag.BoolVar(&forceRegenerateKubeconfigOnAuthFailure, "force-regenerate-kubeconfig-on-auth-failure", false, "Delete and republish a kubeconfig secret once a consumer reports an authentication failure against the management cluster since the secret's last rotation.")
+	flag.BoolVar(&gcJanitorDryRun, "gc-janitor-dry-run", false, "Log and record metrics for orphaned operator-managed resources the GC janitor would delete, without actually deleting them.")
+
+	opts := zap.Options{Development: false}
+	opts.BindFlags(flag.CommandLine)
+	flag.Parse()
+
+	zapLogger := zap.New(zap.UseFlagOptions(&opts))
+	ctrl.SetLogger(zapLogger.WithSink(logredact.NewSink(zapLogger.GetSink())))
+
+	metrics.RegisterWorkqueueMetrics()
+
+	restConfig, err := restConfigFor(bootstrapKubeconfig)
+	if err != nil {
+		setupLog.Error(err, "unable to load kubeconfig")
+		os.Exit(1)
+	}
+
+	mgrOptions := ctrl.Options{
+		Scheme:                 scheme,
+		Metrics:                metricsserver.Options{BindAddress: metricsAddr},
+		HealthProbeBindAddress: probeAddr,
+		LeaderElection:         enableLeaderElection,
+		LeaderElectionID:       "cluster-capi-operator-leader",
+	}
+	if platform.ParseDeploymentMode(deploymentMode) == platform.DeploymentModeNamespaceScoped {
+		mgrOptions.Cache.DefaultNamespaces = map[string]cache.Config{managedNamespace: {}}
+	}
+
+	mgr, err := ctrl.NewManager(restConfig, mgrOptions)
+	if err != nil {
+		setupLog.Error(err, "unable to start manager")
+		os.Exit(1)
+	}
+
+	if err := satoken.IndexByServiceAccount(mgr); err != nil {
+		setupLog.Error(err, "unable to set up service account token secret indexer")
+		os.Exit(1)
+	}
+
+	// writeGate controls whether operatorClient's writes land, covering
+	// both --standby (starts inactive, promoted by SIGUSR1) and pausing
+	// an otherwise-active operator ahead of a clusterctl move (deactivated
+	// by SIGUSR2; see pkg/movereadiness). Wrapping unconditionally keeps
+	// both signals live on every run, not just --standby ones.
+	writeGate := standby.NewGate(!standbyMode)
+	standby.WatchPromoteSignal(writeGate)
+	standby.WatchPauseSignal(writeGate)
+	var operatorClient client.Client = &standby.Client{Client: mgr.GetClient(), Gate: writeGate}
+	if standbyMode {
+		setupLog.Info("starting in standby mode: writes are suppressed until promoted (send SIGUSR1 to promote)")
+	}
+
+	if err := (&kubeconfig.KubeconfigReconciler{
+		Client:                       operatorClient,
+		Namespace:                    managedNamespace,
+		MaxConcurrentReconciles:      kubeconfigConcurrency,
+		PublishCompatibilitySecret:   publishCompatKubeconfig,
+		VerifyTokenBeforePublish:     verifyKubeconfigToken,
+		VerifyEndpointBeforePublish:  verifyKubeconfigEndpoint,
+		ImmutableSecrets:             immutableKubeconfigSecrets,
+		ForceRegenerateOnAuthFailure: forceRegenerateKubeconfigOnAuthFailure,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Kubeconfig")
+		os.Exit(1)
+	}
+
+	if standbyMode {
+		if _, err := namespace.Exists(context.Background(), mgr.GetClient(), managedNamespace); err != nil {
+			setupLog.Error(err, "preflight check failed: unable to check managed namespace")
+			os.Exit(1)
+		}
+	} else {
+		bootstrapClient, err := client.New(mgr.GetConfig(), client.Options{Scheme: scheme})
+		if err != nil {
+			setupLog.Error(err, "unable to create bootstrap client")
+			os.Exit(1)
+		}
+		if err := namespace.Ensure(context.Background(), bootstrapClient, managedNamespace); err != nil {
+			setupLog.Error(err, "unable to ensure managed namespace")
+			os.Exit(1)
+		}
+	}
+
+	statusAggregator := status.NewAggregator()
+	statusAggregator.SetFIPSEnabled(fips.IsEnabled())
+
+	if err := (&clusteroperator.ClusterOperatorReconciler{
+		Client:           operatorClient,
+		StatusAggregator: statusAggregator,
+		PlatformResolver: platform.NewResolver(forcePlatform),
+		CapabilityGetter: func(ctx context.Context) (bool, error) {
+			clusterVersion := &configv1.ClusterVersion{}
+			if err := mgr.GetClient().Get(ctx, client.ObjectKey{Name: "version"}, clusterVersion); err != nil {
+				return false, err
+			}
+			return platform.CapabilityEnabled(clusterVersion.Status.Capabilities.EnabledCapabilities), nil
+		},
+		ImplicitlyEnabled: func(ctx context.Context) (bool, error) {
+			return namespace.Exists(ctx, mgr.GetClient(), managedNamespace)
+		},
+		EnabledCapabilitiesGetter: func(ctx context.Context) ([]configv1.ClusterVersionCapability, error) {
+			clusterVersion := &configv1.ClusterVersion{}
+			if err := mgr.GetClient().Get(ctx, client.ObjectKey{Name: "version"}, clusterVersion); err != nil {
+				return nil, err
+			}
+			return clusterVersion.Status.Capabilities.EnabledCapabilities, nil
+		},
+		DriftOverrideChecker: func(ctx context.Context) (bool, error) {
+			return driftguard.AnyOverridden(ctx, mgr.GetClient(), managedNamespace)
+		},
+		ManagedNamespace: managedNamespace,
+		KubeconfigAuthFailureChecker: func(ctx context.Context) (bool, error) {
+			return kubeconfig.AnyConsumerAuthFailure(ctx, mgr.GetClient(), managedNamespace)
+		},
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterOperator")
+		os.Exit(1)
+	}
+
+	moveChecker := &movereadiness.Checker{Client: mgr.GetClient(), Namespace: managedNamespace, Gate: writeGate}
+
+	platformStatusProvider := platform.NewStatusProvider(mgr.GetClient())
+	if err := mgr.Add(platformStatusProvider); err != nil {
+		setupLog.Error(err, "unable to add platform status provider")
+		os.Exit(1)
+	}
+
+	if err := mgr.Add(&cachebudget.Reporter{
+		Client: mgr.GetClient(),
+		Watched: []cachebudget.Watched{
+			{Name: "machines", List: func() client.ObjectList { return &clusterv1.MachineList{} }},
+			{Name: "machinesets", List: func() client.ObjectList { return &clusterv1.MachineSetList{} }},
+			{Name: "deployments", List: func() client.ObjectList { return &appsv1.DeploymentList{} }},
+			{Name: "secrets", List: func() client.ObjectList { return &corev1.SecretList{} }},
+		},
+	}); err != nil {
+		setupLog.Error(err, "unable to add cache budget reporter")
+		os.Exit(1)
+	}
+
+	if err := mgr.Add(&gcjanitor.Janitor{
+		Client:    mgr.GetClient(),
+		Namespace: managedNamespace,
+		DryRun:    gcJanitorDryRun,
+	}); err != nil {
+		setupLog.Error(err, "unable to add gc janitor")
+		os.Exit(1)
+	}
+
+	go func() {
+		mux := http.NewServeMux()
+		mux.Handle("/status", statusAggregator)
+		mux.Handle("/move-readiness", moveChecker)
+		setupLog.Info("starting status summary endpoint", "address", statusAddr)
+		if err := http.ListenAndServe(statusAddr, mux); err != nil {
+			setupLog.Error(err, "status summary endpoint exited")
+		}
+	}()
+
+	if err := (&adopt.Reconciler{
+		Client:    operatorClient,
+		Namespace: managedNamespace,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Adopt")
+		os.Exit(1)
+	}
+
+	if err := (&clusterinventory.Reconciler{
+		Client: operatorClient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ClusterInventory")
+		os.Exit(1)
+	}
+
+	if err := (&monitoring.PrometheusRuleReconciler{
+		Client:    operatorClient,
+		Namespace: managedNamespace,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "PrometheusRule")
+		os.Exit(1)
+	}
+
+	if err := (&featuregate.Reconciler{
+		Client:    operatorClient,
+		Namespace: managedNamespace,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "FeatureGate")
+		os.Exit(1)
+	}
+
+	if err := (&credentialrotation.Reconciler{
+		Client: operatorClient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "CredentialRotation")
+		os.Exit(1)
+	}
+
+	if err := (&infratags.Reconciler{
+		Client:         operatorClient,
+		Namespace:      managedNamespace,
+		PlatformStatus: platformStatusProvider,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "InfraTags")
+		os.Exit(1)
+	}
+
+	if err := (&machinesetsync.Reconciler{
+		Client:         operatorClient,
+		Namespace:      managedNamespace,
+		PlatformStatus: platformStatusProvider,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "MachineSetSync")
+		os.Exit(1)
+	}
+
+	if err := (&faildomain.Reconciler{
+		Client:    operatorClient,
+		Namespace: managedNamespace,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "FailureDomain")
+		os.Exit(1)
+	}
+
+	if err := (&stalemachine.Reconciler{
+		Client: operatorClient,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "StaleMachine")
+		os.Exit(1)
+	}
+
+	if err := (&machinemetrics.Reconciler{
+		Client:    operatorClient,
+		Namespace: managedNamespace,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "MachineMetrics")
+		os.Exit(1)
+	}
+
+	if err := (&loglevel.Reconciler{
+		Client:    operatorClient,
+		Namespace: managedNamespace,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "LogLevel")
+		os.Exit(1)
+	}
+
+	if err := (&imageoverride.Reconciler{
+		Client:    operatorClient,
+		Namespace: managedNamespace,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ImageOverride")
+		os.Exit(1)
+	}
+
+	if err := (&reencrypt.Reconciler{
+		Client:    operatorClient,
+		Namespace: managedNamespace,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "Reencrypt")
+		os.Exit(1)
+	}
+
+	if err := (&projectedtoken.Reconciler{
+		Client:    operatorClient,
+		Namespace: managedNamespace,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ProjectedToken")
+		os.Exit(1)
+	}
+
+	if err := (&controlplane.Reconciler{
+		Client:    operatorClient,
+		Namespace: managedNamespace,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "ControlPlane")
+		os.Exit(1)
+	}
+
+	if enableSelfSignedCA {
+		if err := (&selfsignedca.Reconciler{
+			Client:            operatorClient,
+			Namespace:         managedNamespace,
+			CASecretName:      "cluster-capi-operator-ca",
+			ServingSecretName: "cluster-capi-operator-webhook-serving-cert",
+			ServiceDNSNames: []string{
+				webhookServiceName,
+				fmt.Sprintf("%s.%s.svc", webhookServiceName, managedNamespace),
+				fmt.Sprintf("%s.%s.svc.cluster.local", webhookServiceName, managedNamespace),
+			},
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "SelfSignedCA")
+			os.Exit(1)
+		}
+	}
+
+	if enableFleetManagement {
+		if err := (&remotecluster.Reconciler{
+			Client:    operatorClient,
+			Namespace: managedNamespace,
+			Scheme:    scheme,
+			Pool:      remoteclusterpool.NewPool(),
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "RemoteCluster")
+			os.Exit(1)
+		}
+	}
+
+	if err := (&webhooks.MachineValidator{
+		ControlPlaneEnabled: enableControlPlaneMachines,
+	}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "Machine")
+		os.Exit(1)
+	}
+
+	if err := (&webhooks.MachineSetValidator{
+		OperatorServiceAccount: fmt.Sprintf("system:serviceaccount:%s:cluster-capi-operator", managedNamespace),
+	}).SetupWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "MachineSet")
+		os.Exit(1)
+	}
+
+	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
+		setupLog.Error(err, "unable to set up health check")
+		os.Exit(1)
+	}
+	if err := mgr.AddReadyzCheck("readyz", healthz.Ping); err != nil {
+		setupLog.Error(err, "unable to set up ready check")
+		os.Exit(1)
+	}
+
+	setupLog.Info("starting manager")
+	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
+		setupLog.Error(err, "problem running manager")
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,42 @@
+// Command render-manifests renders a provider's manifests for a given
+// platform and version to stdout, without requiring a live cluster. It is
+// used in CI to diff rendered output across provider version bumps.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	"github.com/openshift/cluster-capi-operator/pkg/render"
+)
+
+func main() {
+	var platformType string
+	var providerVersion string
+	var namespace string
+
+	flag.StringVar(&platformType, "platform", "", "The PlatformType to render manifests for (required).")
+	flag.StringVar(&providerVersion, "provider-version", "", "The provider version to render manifests for (required).")
+	flag.StringVar(&namespace, "namespace", "openshift-cluster-api", "The namespace manifests are rendered into.")
+	flag.Parse()
+
+	if platformType == "" || providerVersion == "" {
+		fmt.Fprintln(os.Stderr, "--platform and --provider-version are required")
+		os.Exit(2)
+	}
+
+	out, err := render.Render(render.Input{
+		PlatformType:    configv1.PlatformType(platformType),
+		ProviderVersion: providerVersion,
+		Namespace:       namespace,
+	})
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	os.Stdout.Write(out)
+}
@@ -0,0 +1,34 @@
+// Package auditlog records a structured, human-readable trail of every
+// mutation the operator makes to managed resources, independent of the
+// Kubernetes audit log, so that changes can be correlated with controller
+// reconciles during incident review.
+package auditlog
+
+import (
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// Recorder logs OperationResults produced by controllerutil.CreateOrPatch /
+// CreateOrUpdate calls in a consistent, greppable format.
+type Recorder struct {
+	// Controller is the name of the controller making the mutation, used
+	// to attribute the log line.
+	Controller string
+}
+
+// Record logs a single mutation of a resource identified by kind/namespace/
+// name. result is typically the return value of controllerutil.CreateOrPatch.
+func (r Recorder) Record(logger logr.Logger, kind, namespace, name string, result controllerutil.OperationResult) {
+	if result == controllerutil.OperationResultNone {
+		return
+	}
+
+	logger.Info("managed resource mutated",
+		"controller", r.Controller,
+		"kind", kind,
+		"namespace", namespace,
+		"name", name,
+		"operation", string(result),
+	)
+}
@@ -0,0 +1,120 @@
+// Package cachebudget periodically reports how many objects the
+// operator's informer cache holds per GVK, and an approximate resident
+// memory cost, so a watch scoped too broadly (e.g. cluster-wide instead of
+// namespaced) shows up as a visible regression in a metric and log line
+// rather than only as an eventual OOM.
+package cachebudget
+
+import (
+	"context"
+	"time"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/openshift/cluster-capi-operator/pkg/metrics"
+)
+
+// defaultInterval is how often Reporter re-counts cached objects when
+// Interval is unset.
+const defaultInterval = 5 * time.Minute
+
+// defaultThreshold is the per-GVK object count above which Reporter warns
+// when Threshold is unset.
+const defaultThreshold = 10000
+
+// approxBytesPerObject is a rough, deliberately conservative estimate of
+// the in-memory footprint of one cached object (its decoded Go struct
+// plus the informer's internal bookkeeping). It turns an object count
+// into an order-of-magnitude memory number for the log line and metric,
+// not a precise accounting.
+const approxBytesPerObject = 2048
+
+// Watched describes one GVK the Reporter counts objects for.
+type Watched struct {
+	// Name is a short label for the kind, used in logs and metrics (e.g.
+	// "machines").
+	Name string
+
+	// List is a constructor for a fresh, empty list object of the watched
+	// kind; Reporter calls the cached client's List against the result on
+	// every tick.
+	List func() client.ObjectList
+}
+
+// Reporter periodically counts cached objects per GVK listed in Watched
+// and warns when any of them exceeds Threshold. It implements
+// manager.Runnable so it starts and stops with the rest of the manager.
+type Reporter struct {
+	client.Client
+
+	// Watched is the set of kinds to count.
+	Watched []Watched
+
+	// Interval is how often to report. Defaults to defaultInterval if
+	// zero.
+	Interval time.Duration
+
+	// Threshold is the object count per GVK above which a report logs a
+	// warning. Defaults to defaultThreshold if zero.
+	Threshold int
+}
+
+// Start runs the periodic report loop until ctx is cancelled, as required
+// by manager.Runnable.
+func (r *Reporter) Start(ctx context.Context) error {
+	interval := r.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		r.report(ctx)
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// report lists every watched kind once and records its count as a metric,
+// logging a warning for any kind over threshold.
+func (r *Reporter) report(ctx context.Context) {
+	threshold := r.Threshold
+	if threshold <= 0 {
+		threshold = defaultThreshold
+	}
+
+	logger := log.FromContext(ctx)
+
+	for _, watched := range r.Watched {
+		list := watched.List()
+		if err := r.List(ctx, list); err != nil {
+			logger.Error(err, "failed to count cached objects", "kind", watched.Name)
+			continue
+		}
+
+		items, err := apimeta.ExtractList(list)
+		if err != nil {
+			logger.Error(err, "failed to extract cached object list", "kind", watched.Name)
+			continue
+		}
+		objects := len(items)
+
+		approxBytes := int64(objects) * approxBytesPerObject
+		metrics.SetCacheBudget(watched.Name, objects, approxBytes)
+
+		if objects > threshold {
+			logger.Info("cache budget exceeded: watch may be scoped too broadly",
+				"kind", watched.Name, "count", objects, "threshold", threshold, "approxBytes", approxBytes)
+		} else {
+			logger.V(1).Info("cache budget", "kind", watched.Name, "count", objects, "approxBytes", approxBytes)
+		}
+	}
+}
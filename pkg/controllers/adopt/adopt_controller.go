@@ -0,0 +1,107 @@
+// Package adopt lets the operator take over pre-existing Cluster objects
+// created out of band (by the installer or a manual clusterctl init)
+// instead of fighting their creator for ownership or, worse, installing
+// a duplicate set of providers alongside them. A Cluster is adopted only
+// once the operator has been explicitly configured to manage Namespace,
+// evidenced by a ClusterAPIInstallation existing there; adoption then
+// consists of stamping the same identity labels/annotations every
+// operator-created object carries, so every other reconciler that gates
+// on gc.ManagedByLabel picks the Cluster up from its next reconcile.
+package adopt
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	operatorv1alpha1 "github.com/openshift/cluster-capi-operator/api/v1alpha1"
+	"github.com/openshift/cluster-capi-operator/pkg/gc"
+	"github.com/openshift/cluster-capi-operator/pkg/metrics"
+	"github.com/openshift/cluster-capi-operator/pkg/util/resourceapply"
+)
+
+// controllerName is the label value this controller reports under in
+// operator self-metrics.
+const controllerName = "adopt"
+
+// adoptedReason is logged as the reason when a pre-existing Cluster is
+// adopted.
+const adoptedReason = "ClusterAdopted"
+
+// Reconciler adopts every un-managed Cluster in Namespace once a
+// ClusterAPIInstallation confirms the operator is configured to manage
+// it.
+type Reconciler struct {
+	client.Client
+
+	// Namespace is the namespace the operator manages CAPI resources in.
+	Namespace string
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&clusterv1.Cluster{}).
+		Named(controllerName).
+		Complete(r)
+}
+
+// Reconcile adopts req's Cluster if it isn't already operator-managed,
+// isn't being deleted, and a ClusterAPIInstallation exists in Namespace
+// to confirm the operator is meant to manage it.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	start := time.Now()
+	logger := log.FromContext(ctx)
+
+	if req.Namespace != r.Namespace {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+
+	cluster := &clusterv1.Cluster{}
+	err := r.Get(ctx, req.NamespacedName, cluster)
+	if apierrors.IsNotFound(err) {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	if cluster.Labels[gc.ManagedByLabel] == gc.ManagedByValue || !cluster.DeletionTimestamp.IsZero() {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+
+	installations := &operatorv1alpha1.ClusterAPIInstallationList{}
+	if err := r.List(ctx, installations, client.InNamespace(r.Namespace)); err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+	if len(installations.Items) == 0 {
+		logger.V(1).Info("not adopting Cluster: no ClusterAPIInstallation configured", "cluster", cluster.Name)
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+
+	if _, err := resourceapply.CreateOrPatch(ctx, controllerName, r.Client, cluster, func() error {
+		if cluster.Labels == nil {
+			cluster.Labels = map[string]string{}
+		}
+		cluster.Labels[gc.ManagedByLabel] = gc.ManagedByValue
+		return nil
+	}); err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	logger.Info("adopted pre-existing Cluster", "cluster", cluster.Name, "reason", adoptedReason)
+	metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,118 @@
+// Package clusterinventory recomputes a singleton ClusterInventory's
+// status from every CAPI Cluster the operator can see, so admins have
+// one place to check what this hub manages instead of listing Cluster
+// objects across every namespace.
+package clusterinventory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	operatorv1alpha1 "github.com/openshift/cluster-capi-operator/api/v1alpha1"
+	"github.com/openshift/cluster-capi-operator/pkg/controllers/kubeconfig"
+	"github.com/openshift/cluster-capi-operator/pkg/metrics"
+)
+
+// controllerName is the label value this controller reports under in
+// operator self-metrics.
+const controllerName = "clusterinventory"
+
+// SingletonName is the name of the single cluster-scoped
+// ClusterInventory object this controller maintains.
+const SingletonName = "cluster"
+
+// Reconciler recomputes the SingletonName ClusterInventory's status from
+// every CAPI Cluster currently visible to the operator, regardless of
+// which Cluster triggered the reconcile.
+type Reconciler struct {
+	client.Client
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&clusterv1.Cluster{}).
+		Named(controllerName).
+		Complete(r)
+}
+
+// Reconcile rebuilds the ClusterInventory singleton from the current set
+// of Cluster objects.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	start := time.Now()
+	logger := log.FromContext(ctx)
+
+	clusters := &clusterv1.ClusterList{}
+	if err := r.List(ctx, clusters); err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	summaries := make([]operatorv1alpha1.ClusterSummary, 0, len(clusters.Items))
+	for i := range clusters.Items {
+		summaries = append(summaries, r.summarize(ctx, &clusters.Items[i]))
+	}
+	sort.Slice(summaries, func(i, j int) bool {
+		if summaries[i].Namespace != summaries[j].Namespace {
+			return summaries[i].Namespace < summaries[j].Namespace
+		}
+		return summaries[i].Name < summaries[j].Name
+	})
+
+	inventory := &operatorv1alpha1.ClusterInventory{}
+	err := r.Get(ctx, types.NamespacedName{Name: SingletonName}, inventory)
+	if apierrors.IsNotFound(err) {
+		inventory.Name = SingletonName
+		if err := r.Create(ctx, inventory); err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+	} else if err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	now := metav1.Now()
+	inventory.Status.Clusters = summaries
+	inventory.Status.LastUpdated = &now
+	if err := r.Status().Update(ctx, inventory); err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	logger.V(1).Info("recomputed cluster inventory", "clusters", len(summaries))
+	metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+	return ctrl.Result{}, nil
+}
+
+// summarize builds one Cluster's ClusterSummary, looking up its
+// published kubeconfig secret if one exists.
+func (r *Reconciler) summarize(ctx context.Context, cluster *clusterv1.Cluster) operatorv1alpha1.ClusterSummary {
+	summary := operatorv1alpha1.ClusterSummary{
+		Name:      cluster.Name,
+		Namespace: cluster.Namespace,
+		Phase:     cluster.Status.Phase,
+	}
+
+	if ref := cluster.Spec.InfrastructureRef; ref != nil {
+		summary.Platform = ref.Kind
+	}
+
+	secretName := kubeconfig.SecretNameForCluster(cluster.Name)
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: cluster.Namespace, Name: secretName}, secret); err == nil {
+		summary.KubeconfigSecretRef = secretName
+	}
+
+	return summary
+}
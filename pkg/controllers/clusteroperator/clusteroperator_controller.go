@@ -0,0 +1,414 @@
+// Package clusteroperator manages the cluster-api ClusterOperator resource,
+// aggregating the health of the operator's managed controllers into the
+// standard OpenShift Available/Progressing/Degraded/Upgradeable conditions.
+package clusteroperator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	v1helpers "github.com/openshift/library-go/pkg/config/clusteroperator/v1helpers"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/openshift/cluster-capi-operator/pkg/metrics"
+	"github.com/openshift/cluster-capi-operator/pkg/status"
+	"github.com/openshift/cluster-capi-operator/pkg/util/namespace"
+	"github.com/openshift/cluster-capi-operator/pkg/util/platform"
+	reconcileutil "github.com/openshift/cluster-capi-operator/pkg/util/reconcile"
+)
+
+// controllerName is the label value this controller reports under in
+// operator self-metrics.
+const controllerName = "clusteroperator"
+
+// OperatorName is the name of the ClusterOperator resource this controller
+// manages.
+const OperatorName = "cluster-api"
+
+// ClusterOperatorReconciler reconciles the cluster-api ClusterOperator
+// status object.
+type ClusterOperatorReconciler struct {
+	client.Client
+
+	// StatusAggregator, if set, receives the latest ClusterOperator
+	// conditions for the JSON status summary endpoint.
+	StatusAggregator *status.Aggregator
+
+	// PlatformResolver determines whether a development platform override
+	// is in effect, in which case Upgradeable is forced to False.
+	PlatformResolver *platform.Resolver
+
+	// InfrastructureGetter reads the cluster's detected platform type, used
+	// to decide whether the operator is disabled on this platform.
+	InfrastructureGetter func(ctx context.Context) (configv1.PlatformType, error)
+
+	// CapabilityGetter reads whether platform.CapabilityClusterAPI is
+	// enabled in the cluster's ClusterVersion. If nil, the operator assumes
+	// the capability is always enabled (e.g. in environments where
+	// capabilities don't apply).
+	CapabilityGetter func(ctx context.Context) (enabled bool, err error)
+
+	// ImplicitlyEnabled reports whether the operator was already active
+	// before the capability could be disabled (e.g. its managed namespace
+	// already exists), so that a cluster upgrading into capability gating
+	// doesn't silently lose an installation it already has.
+	ImplicitlyEnabled func(ctx context.Context) (bool, error)
+
+	// EnabledCapabilitiesGetter reads the cluster's full set of enabled
+	// ClusterVersion capabilities, used to publish the computed
+	// supported-platform set into the ClusterOperator status Extension
+	// field. If nil, the extension field is left unset.
+	EnabledCapabilitiesGetter func(ctx context.Context) ([]configv1.ClusterVersionCapability, error)
+
+	// DriftOverrideChecker reports whether any operator-managed resource
+	// is currently honoring a manually-edited field instead of reverting
+	// it (see pkg/driftguard). If it returns true, Upgradeable is forced
+	// to False, since the operator's next upgrade may carry a new
+	// default for the overridden field that the honored edit masks. If
+	// nil, this check is skipped.
+	DriftOverrideChecker func(ctx context.Context) (bool, error)
+
+	// ManagedNamespace is the namespace the operator installs providers
+	// into. While it is Terminating, Degraded is set with a clear reason
+	// instead of letting dependent controllers spin on NotFound errors;
+	// once termination completes, the namespace is automatically
+	// recreated and repopulated with its required labels (see
+	// pkg/util/namespace.Ensure). If empty, this check is skipped.
+	ManagedNamespace string
+
+	// KubeconfigAuthFailureChecker reports whether any provider is
+	// currently failing to authenticate against the management cluster
+	// using the kubeconfig this operator published for it (see
+	// pkg/controllers/kubeconfig.AnyConsumerAuthFailure). If it returns
+	// true, Degraded is set with a targeted reason, since a provider that
+	// can't authenticate can't reconcile any of its managed resources. If
+	// nil, this check is skipped.
+	KubeconfigAuthFailureChecker func(ctx context.Context) (bool, error)
+}
+
+// upgradeableFalseReason is set on the Upgradeable condition while a
+// development platform override is active.
+const upgradeableFalseReason = "PlatformOverrideActive"
+
+// driftOverrideUpgradeableFalseReason is set on the Upgradeable condition
+// while a manually-edited field on an operator-managed resource is being
+// honored instead of reverted.
+const driftOverrideUpgradeableFalseReason = "ManagedFieldOverrideActive"
+
+// platformNotSupportedReason is set on the Available condition when the
+// cluster's platform has no Cluster API provider support, mirroring how
+// other optional OpenShift operators report themselves as disabled.
+const platformNotSupportedReason = "PlatformNotSupported"
+
+// degradedRecheckInterval is how often a Degraded ClusterOperator is
+// re-reconciled while no other event would otherwise trigger it, so
+// recovery from an externally-caused failure is picked up without
+// requiring a change to the ClusterOperator object itself.
+const degradedRecheckInterval = 5 * time.Minute
+
+// capabilityDisabledReason is set on the Available condition when the
+// ClusterAPI cluster capability is disabled and the operator was not
+// already active before the capability became gateable.
+const capabilityDisabledReason = "CapabilityDisabled"
+
+// kubeconfigAuthFailureReason is set on the Degraded condition while at
+// least one provider is failing to authenticate against the management
+// cluster using its published kubeconfig.
+const kubeconfigAuthFailureReason = "KubeconfigAuthFailure"
+
+// namespaceTerminatingReason is set on the Degraded condition while
+// ManagedNamespace is Terminating.
+const namespaceTerminatingReason = "ManagedNamespaceTerminating"
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *ClusterOperatorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&configv1.ClusterOperator{}).
+		Watches(&corev1.Namespace{}, handler.EnqueueRequestsFromMapFunc(r.mapNamespaceToClusterOperator)).
+		Named("clusteroperator").
+		Complete(r)
+}
+
+// mapNamespaceToClusterOperator requeues the cluster-api ClusterOperator
+// whenever ManagedNamespace itself changes (including its deletion), so
+// termination is caught immediately rather than waiting for
+// degradedRecheckInterval.
+func (r *ClusterOperatorReconciler) mapNamespaceToClusterOperator(ctx context.Context, obj client.Object) []ctrl.Request {
+	if obj.GetName() != r.ManagedNamespace {
+		return nil
+	}
+	return []ctrl.Request{{NamespacedName: types.NamespacedName{Name: OperatorName}}}
+}
+
+// Reconcile keeps the cluster-api ClusterOperator status in sync with the
+// current state of the operator's managed controllers.
+func (r *ClusterOperatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, cancel := reconcileutil.WithTimeout(ctx)
+	defer cancel()
+
+	start := time.Now()
+	logger := log.FromContext(ctx)
+
+	co := &configv1.ClusterOperator{}
+	err := r.Get(ctx, types.NamespacedName{Name: OperatorName}, co)
+	if apierrors.IsNotFound(err) {
+		logger.Info("ClusterOperator not found, skipping")
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	if r.CapabilityGetter != nil {
+		enabled, err := r.CapabilityGetter(ctx)
+		if err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+
+		if !enabled && r.ImplicitlyEnabled != nil {
+			enabled, err = r.ImplicitlyEnabled(ctx)
+			if err != nil {
+				metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+				return ctrl.Result{}, err
+			}
+			if enabled {
+				logger.Info("ClusterAPI capability is disabled but the operator is already installed; continuing to avoid an implicit uninstall")
+			}
+		}
+
+		if !enabled {
+			v1helpers.SetStatusCondition(&co.Status.Conditions, configv1.ClusterOperatorStatusCondition{
+				Type:    configv1.OperatorAvailable,
+				Status:  configv1.ConditionFalse,
+				Reason:  capabilityDisabledReason,
+				Message: "the ClusterAPI cluster capability is disabled; this operator is a no-op until it is enabled",
+			})
+			if r.StatusAggregator != nil {
+				r.StatusAggregator.SetConditions(co.Status.Conditions)
+			}
+			if err := r.Status().Update(ctx, co); err != nil {
+				metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+				return ctrl.Result{}, err
+			}
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+			return ctrl.Result{}, nil
+		}
+	}
+
+	if r.PlatformResolver != nil && r.PlatformResolver.ForcePlatform != "" {
+		v1helpers.SetStatusCondition(&co.Status.Conditions, configv1.ClusterOperatorStatusCondition{
+			Type:    configv1.OperatorUpgradeable,
+			Status:  configv1.ConditionFalse,
+			Reason:  upgradeableFalseReason,
+			Message: "a development --force-platform override is active; upgrades are not supported in this configuration",
+		})
+		if err := r.Status().Update(ctx, co); err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+	}
+
+	if r.DriftOverrideChecker != nil {
+		overridden, err := r.DriftOverrideChecker(ctx)
+		if err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+		if overridden {
+			v1helpers.SetStatusCondition(&co.Status.Conditions, configv1.ClusterOperatorStatusCondition{
+				Type:    configv1.OperatorUpgradeable,
+				Status:  configv1.ConditionFalse,
+				Reason:  driftOverrideUpgradeableFalseReason,
+				Message: "a manually-edited field on an operator-managed resource is being honored instead of reverted; upgrades are not supported until the override is removed",
+			})
+			if err := r.Status().Update(ctx, co); err != nil {
+				metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+				return ctrl.Result{}, err
+			}
+		}
+	}
+
+	if r.ManagedNamespace != "" {
+		if err := r.reconcileManagedNamespace(ctx, co); err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+	}
+
+	if r.KubeconfigAuthFailureChecker != nil {
+		if err := r.reconcileKubeconfigAuthFailureCondition(ctx, co); err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+	}
+
+	if r.InfrastructureGetter != nil {
+		if err := r.reconcileDisabledCondition(ctx, co); err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+	}
+
+	if r.StatusAggregator != nil {
+		r.StatusAggregator.SetConditions(co.Status.Conditions)
+	}
+
+	degradedReason := degradedReasonFor(co)
+	if degradedReason != "" {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeDegraded, degradedReason, time.Since(start))
+		return ctrl.Result{RequeueAfter: degradedRecheckInterval}, nil
+	}
+
+	metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+	return ctrl.Result{}, nil
+}
+
+// reconcileDisabledCondition sets Available=False with
+// platformNotSupportedReason when the cluster's platform has no Cluster
+// API provider support, or clears that condition otherwise.
+func (r *ClusterOperatorReconciler) reconcileDisabledCondition(ctx context.Context, co *configv1.ClusterOperator) error {
+	platformType, err := r.InfrastructureGetter(ctx)
+	if err != nil {
+		return err
+	}
+
+	if platform.IsSupported(platformType) {
+		v1helpers.SetStatusCondition(&co.Status.Conditions, configv1.ClusterOperatorStatusCondition{
+			Type:   configv1.OperatorAvailable,
+			Status: configv1.ConditionTrue,
+		})
+	} else {
+		v1helpers.SetStatusCondition(&co.Status.Conditions, configv1.ClusterOperatorStatusCondition{
+			Type:    configv1.OperatorAvailable,
+			Status:  configv1.ConditionFalse,
+			Reason:  platformNotSupportedReason,
+			Message: "Cluster API has no provider support for this platform and is disabled",
+		})
+	}
+
+	if r.EnabledCapabilitiesGetter != nil {
+		enabledCapabilities, err := r.EnabledCapabilitiesGetter(ctx)
+		if err != nil {
+			return err
+		}
+		if err := setSupportedPlatformsExtension(co, platformType, enabledCapabilities); err != nil {
+			return err
+		}
+	}
+
+	return r.Status().Update(ctx, co)
+}
+
+// reconcileManagedNamespace sets Degraded=True with namespaceTerminatingReason
+// while ManagedNamespace is Terminating, so dependent controllers spinning
+// on NotFound errors have a clear root cause instead of silent retries.
+// Once the namespace is fully gone, it is automatically recreated and
+// repopulated with its required labels, and Degraded is cleared.
+func (r *ClusterOperatorReconciler) reconcileManagedNamespace(ctx context.Context, co *configv1.ClusterOperator) error {
+	logger := log.FromContext(ctx)
+
+	ns := &corev1.Namespace{}
+	err := r.Get(ctx, types.NamespacedName{Name: r.ManagedNamespace}, ns)
+	if apierrors.IsNotFound(err) {
+		logger.Info("managed namespace is gone, recreating it", "namespace", r.ManagedNamespace)
+		if err := namespace.Ensure(ctx, r.Client, r.ManagedNamespace); err != nil {
+			return err
+		}
+		v1helpers.SetStatusCondition(&co.Status.Conditions, configv1.ClusterOperatorStatusCondition{
+			Type:   configv1.OperatorDegraded,
+			Status: configv1.ConditionFalse,
+		})
+		return r.Status().Update(ctx, co)
+	}
+	if err != nil {
+		return err
+	}
+
+	if ns.Status.Phase == corev1.NamespaceTerminating {
+		v1helpers.SetStatusCondition(&co.Status.Conditions, configv1.ClusterOperatorStatusCondition{
+			Type:    configv1.OperatorDegraded,
+			Status:  configv1.ConditionTrue,
+			Reason:  namespaceTerminatingReason,
+			Message: fmt.Sprintf("managed namespace %q is terminating; it will be recreated and repopulated automatically once termination completes", r.ManagedNamespace),
+		})
+	} else {
+		v1helpers.SetStatusCondition(&co.Status.Conditions, configv1.ClusterOperatorStatusCondition{
+			Type:   configv1.OperatorDegraded,
+			Status: configv1.ConditionFalse,
+		})
+	}
+
+	return r.Status().Update(ctx, co)
+}
+
+// reconcileKubeconfigAuthFailureCondition sets Degraded=True with
+// kubeconfigAuthFailureReason while KubeconfigAuthFailureChecker reports a
+// provider failing to authenticate with its published kubeconfig, and
+// clears it once resolved. It only clears a Degraded condition carrying
+// kubeconfigAuthFailureReason, so it doesn't reset one set by
+// reconcileManagedNamespace; while a failure is reported it overwrites
+// whatever Degraded condition is present.
+func (r *ClusterOperatorReconciler) reconcileKubeconfigAuthFailureCondition(ctx context.Context, co *configv1.ClusterOperator) error {
+	failing, err := r.KubeconfigAuthFailureChecker(ctx)
+	if err != nil {
+		return err
+	}
+
+	if failing {
+		v1helpers.SetStatusCondition(&co.Status.Conditions, configv1.ClusterOperatorStatusCondition{
+			Type:    configv1.OperatorDegraded,
+			Status:  configv1.ConditionTrue,
+			Reason:  kubeconfigAuthFailureReason,
+			Message: "a provider is failing to authenticate against the management cluster using its published kubeconfig",
+		})
+		return r.Status().Update(ctx, co)
+	}
+
+	if degradedReasonFor(co) == kubeconfigAuthFailureReason {
+		v1helpers.SetStatusCondition(&co.Status.Conditions, configv1.ClusterOperatorStatusCondition{
+			Type:   configv1.OperatorDegraded,
+			Status: configv1.ConditionFalse,
+		})
+		return r.Status().Update(ctx, co)
+	}
+
+	return nil
+}
+
+// setSupportedPlatformsExtension publishes the operator's computed
+// supported-platform set for platformType into co.Status.Extension, so
+// admins and tooling can discover whether Cluster API will activate on
+// their cluster without reading operator logs or source code.
+func setSupportedPlatformsExtension(co *configv1.ClusterOperator, platformType configv1.PlatformType, enabledCapabilities []configv1.ClusterVersionCapability) error {
+	raw, err := json.Marshal(platform.ComputeSupportedPlatformsStatus(platformType, enabledCapabilities))
+	if err != nil {
+		return err
+	}
+
+	co.Status.Extension = runtime.RawExtension{Raw: raw}
+	return nil
+}
+
+// degradedReasonFor returns the reason of the ClusterOperator's Degraded
+// condition, or the empty string if the operator is not degraded.
+func degradedReasonFor(co *configv1.ClusterOperator) string {
+	for _, cond := range co.Status.Conditions {
+		if cond.Type == configv1.OperatorDegraded && cond.Status == configv1.ConditionTrue {
+			return cond.Reason
+		}
+	}
+	return ""
+}
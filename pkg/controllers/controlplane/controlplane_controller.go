@@ -0,0 +1,117 @@
+// Package controlplane bridges OpenShift's ControlPlaneMachineSet into
+// Cluster API's external control-plane provider contract, so a CAPI
+// Cluster object reports accurate control-plane readiness even though
+// this operator does not yet manage control-plane Machines itself.
+package controlplane
+
+import (
+	"context"
+	"time"
+
+	machinev1 "github.com/openshift/api/machine/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/openshift/cluster-capi-operator/pkg/gc"
+	"github.com/openshift/cluster-capi-operator/pkg/metrics"
+)
+
+// controllerName is the label value this controller reports under in
+// operator self-metrics.
+const controllerName = "controlplane"
+
+// controlPlaneMachineSetName is the only ControlPlaneMachineSet name the
+// machine-api operator will reconcile, and therefore the only one this
+// controller needs to read.
+const controlPlaneMachineSetName = "cluster"
+
+// openShiftControlPlaneGVK identifies the externally-managed control-plane
+// provider object this operator publishes. CAPI core reads its
+// status.ready and status.initialized fields to populate
+// Cluster.status.controlPlaneReady, per the external control-plane
+// provider contract.
+var openShiftControlPlaneGVK = schema.GroupVersionKind{Group: "controlplane.cluster.x-k8s.io", Version: "v1beta1", Kind: "OpenShiftControlPlane"}
+
+// Reconciler mirrors the readiness of the cluster's ControlPlaneMachineSet
+// onto every operator-managed OpenShiftControlPlane object.
+type Reconciler struct {
+	client.Client
+
+	// Namespace is the namespace the operator manages provider resources
+	// in, which is where the OpenShiftControlPlane objects live.
+	Namespace string
+
+	// MAPINamespace is the namespace the ControlPlaneMachineSet lives in.
+	// Defaults to "openshift-machine-api" if unset.
+	MAPINamespace string
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		For(&machinev1.ControlPlaneMachineSet{}).
+		Complete(r)
+}
+
+// Reconcile reads the cluster's ControlPlaneMachineSet status and stamps
+// its readiness onto every operator-managed OpenShiftControlPlane object.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	start := time.Now()
+	logger := log.FromContext(ctx)
+
+	mapiNamespace := r.MAPINamespace
+	if mapiNamespace == "" {
+		mapiNamespace = "openshift-machine-api"
+	}
+
+	cpms := &machinev1.ControlPlaneMachineSet{}
+	key := types.NamespacedName{Namespace: mapiNamespace, Name: controlPlaneMachineSetName}
+	if err := r.Get(ctx, key, cpms); err != nil {
+		if apierrors.IsNotFound(err) {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+			return ctrl.Result{}, nil
+		}
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	initialized := cpms.Status.Replicas > 0
+	ready := initialized && cpms.Status.ReadyReplicas == cpms.Status.Replicas
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(openShiftControlPlaneGVK)
+	if err := r.List(ctx, list, client.InNamespace(r.Namespace)); err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	for i := range list.Items {
+		obj := &list.Items[i]
+		if obj.GetLabels()[gc.ManagedByLabel] != gc.ManagedByValue {
+			continue
+		}
+
+		if err := unstructured.SetNestedField(obj.Object, ready, "status", "ready"); err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+		if err := unstructured.SetNestedField(obj.Object, initialized, "status", "initialized"); err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+		if err := r.Status().Update(ctx, obj); err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+	}
+
+	logger.V(1).Info("synced control plane readiness", "ready", ready, "initialized", initialized)
+	metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+	return ctrl.Result{}, nil
+}
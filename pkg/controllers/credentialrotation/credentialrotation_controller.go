@@ -0,0 +1,119 @@
+// Package credentialrotation restarts provider Deployments when their
+// synced cloud credential secret changes, since most providers read
+// credentials once at startup and do not watch their mounted secret for
+// updates.
+package credentialrotation
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sort"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/openshift/cluster-capi-operator/pkg/gc"
+	"github.com/openshift/cluster-capi-operator/pkg/metrics"
+	"github.com/openshift/cluster-capi-operator/pkg/util/resourceapply"
+	"github.com/openshift/cluster-capi-operator/pkg/util/secretsync"
+)
+
+// controllerName is the label value this controller reports under in
+// operator self-metrics.
+const controllerName = "credentialrotation"
+
+// checksumAnnotation records the checksum of the credential secret content
+// last applied to a provider Deployment's pod template, so changing it is
+// what actually triggers the rolling restart.
+const checksumAnnotation = "cluster-api.openshift.io/credentials-checksum"
+
+// Reconciler rolls every operator-managed provider Deployment in a
+// credential secret's namespace whenever that secret's content changes.
+type Reconciler struct {
+	client.Client
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Secret{}).
+		Named("credentialrotation").
+		Complete(r)
+}
+
+// Reconcile recomputes the credential checksum for a synced secret and
+// stamps it onto every provider Deployment sharing its namespace, so
+// provider pods restart and pick up the rotated credentials.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	start := time.Now()
+	logger := log.FromContext(ctx)
+
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, req.NamespacedName, secret)
+	if apierrors.IsNotFound(err) {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	if _, ok := secret.Annotations[secretsync.SourceAnnotation]; !ok {
+		return ctrl.Result{}, nil
+	}
+
+	checksum := dataChecksum(secret.Data)
+
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, client.InNamespace(secret.Namespace)); err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		if deployment.Labels[gc.ManagedByLabel] != gc.ManagedByValue {
+			continue
+		}
+
+		if _, err := resourceapply.CreateOrPatch(ctx, controllerName, r.Client, deployment, func() error {
+			if deployment.Spec.Template.Annotations == nil {
+				deployment.Spec.Template.Annotations = map[string]string{}
+			}
+			deployment.Spec.Template.Annotations[checksumAnnotation] = checksum
+			return nil
+		}); err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+	}
+
+	logger.V(1).Info("rolled provider deployments for rotated credentials", "secret", secret.Name)
+	metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+	return ctrl.Result{}, nil
+}
+
+// dataChecksum returns a short, deterministic checksum of a secret's data,
+// sorted by key so the result doesn't depend on map iteration order.
+func dataChecksum(data map[string][]byte) string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	h := sha256.New()
+	for _, k := range keys {
+		h.Write([]byte(k))
+		h.Write(data[k])
+	}
+
+	return hex.EncodeToString(h.Sum(nil))[:16]
+}
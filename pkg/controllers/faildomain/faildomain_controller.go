@@ -0,0 +1,124 @@
+// Package faildomain populates failure domains on operator-managed
+// AWSCluster objects from the availability zones discovered across the
+// cluster's existing MAPI MachineSets, so CAPI's Machine placement and
+// MachineDeployment spreading reflect the zones this cluster actually
+// spans instead of defaulting to a single one.
+package faildomain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/openshift/cluster-capi-operator/pkg/discovery"
+	"github.com/openshift/cluster-capi-operator/pkg/gc"
+	"github.com/openshift/cluster-capi-operator/pkg/metrics"
+)
+
+// controllerName is the label value this controller reports under in
+// operator self-metrics.
+const controllerName = "faildomain"
+
+// awsClusterGVK identifies the AWSCluster CAPA infrastructure object this
+// controller populates failure domains on.
+var awsClusterGVK = schema.GroupVersionKind{Group: "infrastructure.cluster.x-k8s.io", Version: "v1beta2", Kind: "AWSCluster"}
+
+// Reconciler populates every operator-managed AWSCluster's
+// status.failureDomains from the zones discovered by pkg/discovery.
+type Reconciler struct {
+	client.Client
+
+	// Namespace is the namespace the operator manages provider resources
+	// in. MAPI MachineSets are discovered from MAPINamespace instead.
+	Namespace string
+
+	// MAPINamespace is the namespace MAPI MachineSets live in. Defaults to
+	// "openshift-machine-api" if unset.
+	MAPINamespace string
+}
+
+// SetupWithManager sets up the controller with the Manager. Reconciliation
+// is periodic rather than watch-driven, since zone discovery depends on
+// MAPI MachineSets this controller doesn't otherwise have a reason to
+// watch.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("faildomain").
+		Complete(r)
+}
+
+// Reconcile discovers the cluster's AWS availability zones and stamps them
+// onto every operator-managed AWSCluster's status.failureDomains.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	start := time.Now()
+	logger := log.FromContext(ctx)
+
+	mapiNamespace := r.MAPINamespace
+	if mapiNamespace == "" {
+		mapiNamespace = "openshift-machine-api"
+	}
+
+	zones, err := discovery.DiscoverAWSZones(ctx, r.Client, mapiNamespace)
+	if err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+	if len(zones) == 0 {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+
+	domains, err := toUnstructuredFailureDomains(discovery.FailureDomainsFromZones(zones))
+	if err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(awsClusterGVK)
+	if err := r.List(ctx, list, client.InNamespace(r.Namespace)); err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	for i := range list.Items {
+		obj := &list.Items[i]
+		if obj.GetLabels()[gc.ManagedByLabel] != gc.ManagedByValue {
+			continue
+		}
+
+		if err := unstructured.SetNestedMap(obj.Object, domains, "status", "failureDomains"); err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+		if err := r.Status().Update(ctx, obj); err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+	}
+
+	logger.V(1).Info("populated AWSCluster failure domains", "zones", len(zones))
+	metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+	return ctrl.Result{}, nil
+}
+
+// toUnstructuredFailureDomains round-trips domains through JSON into the
+// map[string]interface{} shape unstructured.SetNestedMap requires.
+func toUnstructuredFailureDomains(domains map[string]discovery.FailureDomainSpec) (map[string]interface{}, error) {
+	raw, err := json.Marshal(domains)
+	if err != nil {
+		return nil, err
+	}
+
+	var out map[string]interface{}
+	if err := json.Unmarshal(raw, &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
@@ -0,0 +1,103 @@
+// Package featuregate reconciles provider feature-gate settings from a
+// ConfigMap into each managed provider Deployment's pod template, rolling
+// providers when the rendered feature-gate argument changes.
+package featuregate
+
+import (
+	"context"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/openshift/cluster-capi-operator/pkg/gc"
+	"github.com/openshift/cluster-capi-operator/pkg/metrics"
+	"github.com/openshift/cluster-capi-operator/pkg/providers"
+	"github.com/openshift/cluster-capi-operator/pkg/util/resourceapply"
+)
+
+// controllerName is the label value this controller reports under in
+// operator self-metrics.
+const controllerName = "featuregate"
+
+// checksumAnnotation records the checksum of the feature gates last applied
+// to a provider Deployment's pod template, so changing it is what actually
+// triggers the rolling restart.
+const checksumAnnotation = "cluster-api.openshift.io/feature-gates-checksum"
+
+// Reconciler reconciles providers.FeatureGateConfigMapName into every
+// operator-managed provider Deployment in Namespace.
+type Reconciler struct {
+	client.Client
+
+	// Namespace is the namespace the operator manages provider Deployments
+	// in.
+	Namespace string
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.ConfigMap{}).
+		Named("featuregate").
+		Complete(r)
+}
+
+// Reconcile stamps the current feature-gate checksum onto every
+// operator-managed provider Deployment, so that changing a feature gate
+// rolls providers without the operator needing to know each provider's
+// specific container args layout.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	start := time.Now()
+	logger := log.FromContext(ctx)
+
+	if req.Name != providers.FeatureGateConfigMapName {
+		return ctrl.Result{}, nil
+	}
+
+	cm := &corev1.ConfigMap{}
+	err := r.Get(ctx, req.NamespacedName, cm)
+	if apierrors.IsNotFound(err) {
+		logger.Info("feature gate ConfigMap not found, nothing to roll")
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	checksum := providers.FeatureGateChecksum(providers.FeatureGatesFromConfigMap(cm))
+
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, client.InNamespace(r.Namespace)); err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		if deployment.Labels[gc.ManagedByLabel] != gc.ManagedByValue {
+			continue
+		}
+
+		if _, err := resourceapply.CreateOrPatch(ctx, controllerName, r.Client, deployment, func() error {
+			if deployment.Spec.Template.Annotations == nil {
+				deployment.Spec.Template.Annotations = map[string]string{}
+			}
+			deployment.Spec.Template.Annotations[checksumAnnotation] = checksum
+			return nil
+		}); err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+	}
+
+	metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+	return ctrl.Result{}, nil
+}
+
@@ -0,0 +1,126 @@
+// Package gcjanitor periodically reaps operator-managed resources whose
+// desired-state source has disappeared: a kubeconfig secret (and its
+// compatibility mirror) stamped with gc.ClusterNameLabel for a CAPI
+// Cluster that no longer exists. It implements manager.Runnable rather
+// than a watch-driven Reconciler, since there is no single triggering
+// object whose deletion should fire this sweep — a Cluster's removal,
+// not any change to the secrets themselves, is what creates the orphan.
+package gcjanitor
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/openshift/cluster-capi-operator/pkg/gc"
+	"github.com/openshift/cluster-capi-operator/pkg/metrics"
+)
+
+// defaultInterval is how often Janitor sweeps for orphaned resources when
+// Interval is unset.
+const defaultInterval = 10 * time.Minute
+
+// Janitor periodically deletes operator-managed Secrets (identified by
+// gc.ManagedByLabel and carrying gc.ClusterNameLabel) whose named Cluster
+// no longer exists. It implements manager.Runnable so it starts and
+// stops with the rest of the manager.
+type Janitor struct {
+	client.Client
+
+	// Namespace is the namespace the operator manages resources in, and
+	// the only namespace swept.
+	Namespace string
+
+	// Interval is how often to sweep. Defaults to defaultInterval if
+	// zero.
+	Interval time.Duration
+
+	// DryRun, when set, logs and records metrics for every resource that
+	// would be deleted without actually deleting it.
+	DryRun bool
+}
+
+// Start runs the periodic sweep loop until ctx is cancelled, as required
+// by manager.Runnable.
+func (j *Janitor) Start(ctx context.Context) error {
+	interval := j.Interval
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := j.sweep(ctx); err != nil {
+			log.FromContext(ctx).Error(err, "gc janitor sweep failed")
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// sweep lists every operator-managed Secret in Namespace, builds the set
+// of Secrets whose gc.ClusterNameLabel still names a live Cluster, and
+// deletes (or, under DryRun, only counts) the rest.
+func (j *Janitor) sweep(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	clusters := &clusterv1.ClusterList{}
+	if err := j.List(ctx, clusters, client.InNamespace(j.Namespace)); err != nil {
+		return err
+	}
+	liveClusters := make(map[string]bool, len(clusters.Items))
+	for _, cluster := range clusters.Items {
+		liveClusters[cluster.Name] = true
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := j.List(ctx, secrets, client.InNamespace(j.Namespace), client.MatchingLabels{gc.ManagedByLabel: gc.ManagedByValue}); err != nil {
+		return err
+	}
+
+	items := make([]client.Object, 0, len(secrets.Items))
+	keep := make(map[types.NamespacedName]bool, len(secrets.Items))
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		items = append(items, secret)
+
+		clusterName, ok := secret.Labels[gc.ClusterNameLabel]
+		if !ok || liveClusters[clusterName] {
+			keep[types.NamespacedName{Namespace: secret.Namespace, Name: secret.Name}] = true
+		}
+	}
+
+	if j.DryRun {
+		var orphaned int
+		for _, obj := range items {
+			key := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+			if !keep[key] {
+				orphaned++
+				logger.Info("gc janitor dry-run: would delete orphaned secret", "namespace", obj.GetNamespace(), "name", obj.GetName())
+			}
+		}
+		metrics.ObserveGCCollection("secret", true, orphaned)
+		return nil
+	}
+
+	deleted, err := gc.Collect(ctx, j.Client, items, keep)
+	if err != nil {
+		return err
+	}
+	metrics.ObserveGCCollection("secret", false, deleted)
+	if deleted > 0 {
+		logger.Info("gc janitor deleted orphaned secrets", "count", deleted)
+	}
+	return nil
+}
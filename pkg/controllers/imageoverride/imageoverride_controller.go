@@ -0,0 +1,149 @@
+// Package imageoverride reconciles the image overrides requested on a
+// ClusterAPIInstallation into every managed provider Deployment's
+// container images, so swapping an image for debugging or a hotfix
+// doesn't require a manual deployment edit the operator would otherwise
+// revert on its next reconcile. A manual edit away from the requested
+// override is itself reverted (see pkg/driftguard), unless the Deployment
+// opts into honoring it via driftguard.OverrideAnnotation.
+package imageoverride
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	operatorv1alpha1 "github.com/openshift/cluster-capi-operator/api/v1alpha1"
+	"github.com/openshift/cluster-capi-operator/pkg/driftguard"
+	"github.com/openshift/cluster-capi-operator/pkg/gc"
+	"github.com/openshift/cluster-capi-operator/pkg/metrics"
+	"github.com/openshift/cluster-capi-operator/pkg/util/resourceapply"
+)
+
+// controllerName is the label value this controller reports under in
+// operator self-metrics.
+const controllerName = "imageoverride"
+
+// imageDriftRevertedReason is the Event reason recorded when a manually
+// edited container image is reverted back to the requested override.
+const imageDriftRevertedReason = "ImageOverrideDriftReverted"
+
+// Reconciler reconciles a ClusterAPIInstallation's ImageOverrides into
+// every operator-managed provider Deployment in Namespace.
+type Reconciler struct {
+	client.Client
+
+	// Namespace is the namespace the operator manages provider Deployments
+	// in.
+	Namespace string
+
+	// Recorder emits an Event when a manually edited container image is
+	// detected and reverted back to the requested override. If nil, drift
+	// is still reverted but no Event is recorded.
+	Recorder record.EventRecorder
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor(controllerName)
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&operatorv1alpha1.ClusterAPIInstallation{}).
+		Named(controllerName).
+		Complete(r)
+}
+
+// Reconcile patches the requested image overrides into every matching
+// container on every operator-managed provider Deployment. A container
+// whose name has no entry in ImageOverrides is left untouched, so the
+// operator's default image keeps applying to the rest of the Deployment.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	start := time.Now()
+	logger := log.FromContext(ctx)
+
+	installation := &operatorv1alpha1.ClusterAPIInstallation{}
+	err := r.Get(ctx, req.NamespacedName, installation)
+	if apierrors.IsNotFound(err) {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	if len(installation.Spec.ImageOverrides) == 0 {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, client.InNamespace(r.Namespace)); err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		if deployment.Labels[gc.ManagedByLabel] != gc.ManagedByValue {
+			continue
+		}
+
+		if _, err := resourceapply.CreateOrPatch(ctx, controllerName, r.Client, deployment, func() error {
+			overridden := r.applyImageOverrides(deployment, installation.Spec.ImageOverrides)
+			driftguard.Report(deployment, overridden)
+			gc.StampManagedFields(deployment, "/spec/template/spec/containers/image")
+			return nil
+		}); err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+	}
+
+	logger.V(1).Info("synced provider image overrides", "count", len(installation.Spec.ImageOverrides))
+	metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+	return ctrl.Result{}, nil
+}
+
+// applyImageOverrides sets each container's image to its entry in
+// overrides, if any. A container whose name has no entry is left
+// untouched. If a container's live image was hand-edited away from its
+// override, the edit is reverted and an Event recorded, unless deployment
+// carries driftguard.OverrideAnnotation, in which case the edit is
+// honored instead and the container's image path is returned so the
+// caller can report it via driftguard.Report.
+func (r *Reconciler) applyImageOverrides(deployment *appsv1.Deployment, overrides map[string]string) []string {
+	allowDrift := driftguard.Allowed(deployment)
+
+	var overriddenPaths []string
+	containers := deployment.Spec.Template.Spec.Containers
+	for i := range containers {
+		override, ok := overrides[containers[i].Name]
+		if !ok || containers[i].Image == override {
+			continue
+		}
+
+		if allowDrift {
+			overriddenPaths = append(overriddenPaths, fmt.Sprintf("/spec/template/spec/containers/%d/image", i))
+			continue
+		}
+
+		live := containers[i].Image
+		containers[i].Image = override
+		if r.Recorder != nil {
+			r.Recorder.Eventf(deployment, corev1.EventTypeWarning, imageDriftRevertedReason,
+				"container %q image was manually changed to %q; reverted to the requested override %q", containers[i].Name, live, override)
+		}
+	}
+
+	return overriddenPaths
+}
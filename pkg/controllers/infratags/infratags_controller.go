@@ -0,0 +1,141 @@
+// Package infratags mirrors OpenShift's cluster-wide infrastructure
+// resource tags into the operator-generated AWSCluster and
+// AWSMachineTemplate objects, so resources CAPI providers create inherit
+// the same tagging policy as the rest of the cluster.
+package infratags
+
+import (
+	"context"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/openshift/cluster-capi-operator/pkg/gc"
+	"github.com/openshift/cluster-capi-operator/pkg/metrics"
+	"github.com/openshift/cluster-capi-operator/pkg/providers"
+	"github.com/openshift/cluster-capi-operator/pkg/util/infrawatch"
+	"github.com/openshift/cluster-capi-operator/pkg/util/platform"
+	"github.com/openshift/cluster-capi-operator/pkg/util/resourceapply"
+)
+
+// controllerName is the label value this controller reports under in
+// operator self-metrics.
+const controllerName = "infratags"
+
+var (
+	awsClusterGVK         = schema.GroupVersionKind{Group: "infrastructure.cluster.x-k8s.io", Version: "v1beta2", Kind: "AWSCluster"}
+	awsMachineTemplateGVK = schema.GroupVersionKind{Group: "infrastructure.cluster.x-k8s.io", Version: "v1beta2", Kind: "AWSMachineTemplate"}
+)
+
+// platformStatusWaiter is satisfied by platform.StatusProvider. It lets
+// Reconciler block until the cluster's platform.Context is resolved
+// instead of handling a still-empty Infrastructure.Status ad hoc on every
+// call.
+type platformStatusWaiter interface {
+	Wait(ctx context.Context) (platform.Context, error)
+}
+
+// Reconciler mirrors the Infrastructure object's AWS resource tags into
+// every operator-managed AWSCluster and AWSMachineTemplate in Namespace.
+type Reconciler struct {
+	client.Client
+
+	// Namespace is the namespace the operator manages provider resources
+	// in.
+	Namespace string
+
+	// PlatformStatus, if set, is waited on before reconciling, so a fresh
+	// install whose Infrastructure.Status hasn't populated yet doesn't
+	// reconcile against an AWS-tags helper that's still returning no
+	// tags. If nil, this wait is skipped.
+	PlatformStatus platformStatusWaiter
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&configv1.Infrastructure{}, builder.WithPredicates(infrawatch.RelevantChange())).
+		Named("infratags").
+		Complete(r)
+}
+
+// Reconcile propagates the cluster's AWS resource tags into operator-owned
+// AWSCluster and AWSMachineTemplate objects via their spec.additionalTags
+// field, which providers merge onto every resource they create.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	start := time.Now()
+	logger := log.FromContext(ctx)
+
+	if r.PlatformStatus != nil {
+		platformCtx, err := r.PlatformStatus.Wait(ctx)
+		if err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+		if platformCtx.Type != configv1.AWSPlatformType {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+			return ctrl.Result{}, nil
+		}
+	}
+
+	infra := &configv1.Infrastructure{}
+	if err := r.Get(ctx, req.NamespacedName, infra); err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	tags := providers.AWSResourceTags(infra)
+	if tags == nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.applyTags(ctx, awsClusterGVK, tags, "spec", "additionalTags"); err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+	if err := r.applyTags(ctx, awsMachineTemplateGVK, tags, "spec", "template", "spec", "additionalTags"); err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	logger.V(1).Info("mirrored AWS resource tags into infrastructure templates", "tags", len(tags))
+	metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+	return ctrl.Result{}, nil
+}
+
+// applyTags patches tags into fieldPath on every operator-managed object of
+// gvk in r.Namespace.
+func (r *Reconciler) applyTags(ctx context.Context, gvk schema.GroupVersionKind, tags map[string]string, fieldPath ...string) error {
+	list := &unstructured.UnstructuredList{}
+	list.SetGroupVersionKind(gvk)
+
+	if err := r.List(ctx, list, client.InNamespace(r.Namespace)); err != nil {
+		if meta.IsNoMatchError(err) {
+			return nil
+		}
+		return err
+	}
+
+	for i := range list.Items {
+		obj := &list.Items[i]
+		if obj.GetLabels()[gc.ManagedByLabel] != gc.ManagedByValue {
+			continue
+		}
+
+		if _, err := resourceapply.CreateOrPatch(ctx, controllerName, r.Client, obj, func() error {
+			return unstructured.SetNestedStringMap(obj.Object, tags, fieldPath...)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,120 @@
+package kubeconfig
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// consumingPods returns the names of Pods in namespace that mount
+// secretName as a volume, so rotation impact can be reported against the
+// workloads actually affected rather than guessed at.
+func consumingPods(ctx context.Context, c client.Client, namespace, secretName string) ([]string, error) {
+	pods := &corev1.PodList{}
+	if err := c.List(ctx, pods, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list pods to find kubeconfig consumers: %w", err)
+	}
+
+	var consumers []string
+	for _, pod := range pods.Items {
+		if mountsSecret(&pod, secretName) {
+			consumers = append(consumers, pod.Name)
+		}
+	}
+
+	sort.Strings(consumers)
+	return consumers, nil
+}
+
+// mountsSecret reports whether pod has a volume backed by secretName.
+func mountsSecret(pod *corev1.Pod, secretName string) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.Secret != nil && volume.Secret.SecretName == secretName {
+			return true
+		}
+	}
+	return false
+}
+
+// authFailureKeywords are matched, case-insensitively, against a consumer
+// Pod's Events to recognize an authentication failure against the
+// management cluster. Providers surface a rejected credential as a
+// Warning Event on themselves (e.g. from a failed health check or
+// reconcile against the API server) rather than through any status field
+// this operator could read directly.
+var authFailureKeywords = []string{"401", "unauthorized", "authentication failed"}
+
+// consumerAuthFailures returns the names of consumers (as returned by
+// consumingPods) that have an Event, newer than since, matching
+// authFailureKeywords. since is normally the kubeconfig secret's last
+// rotation time, so a failure caused by a credential that predates the
+// current kubeconfig content doesn't keep getting reported after the
+// rotation that should have fixed it.
+func consumerAuthFailures(ctx context.Context, c client.Client, namespace string, consumers []string, since time.Time) ([]string, error) {
+	if len(consumers) == 0 {
+		return nil, nil
+	}
+
+	byPod := make(map[string]bool, len(consumers))
+	for _, name := range consumers {
+		byPod[name] = false
+	}
+
+	events := &corev1.EventList{}
+	if err := c.List(ctx, events, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list events to find kubeconfig consumer auth failures: %w", err)
+	}
+
+	for _, event := range events.Items {
+		if event.InvolvedObject.Kind != "Pod" {
+			continue
+		}
+		if _, tracked := byPod[event.InvolvedObject.Name]; !tracked {
+			continue
+		}
+		if eventTime(&event).Before(since) {
+			continue
+		}
+		if matchesAuthFailure(event.Reason) || matchesAuthFailure(event.Message) {
+			byPod[event.InvolvedObject.Name] = true
+		}
+	}
+
+	var failures []string
+	for name, failed := range byPod {
+		if failed {
+			failures = append(failures, name)
+		}
+	}
+
+	sort.Strings(failures)
+	return failures, nil
+}
+
+// matchesAuthFailure reports whether s contains any authFailureKeywords.
+func matchesAuthFailure(s string) bool {
+	lower := strings.ToLower(s)
+	for _, keyword := range authFailureKeywords {
+		if strings.Contains(lower, keyword) {
+			return true
+		}
+	}
+	return false
+}
+
+// eventTime returns event's LastTimestamp if set, otherwise its EventTime,
+// otherwise its FirstTimestamp. Series is not consulted.
+func eventTime(event *corev1.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.FirstTimestamp.Time
+}
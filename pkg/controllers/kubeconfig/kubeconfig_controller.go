@@ -0,0 +1,516 @@
+// Package kubeconfig contains the controller that publishes a kubeconfig
+// secret for the Cluster API management cluster so that infrastructure
+// providers and operators can talk to it without reaching for the
+// cluster-admin kubeconfig.
+package kubeconfig
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	operatorv1alpha1 "github.com/openshift/cluster-capi-operator/api/v1alpha1"
+	"github.com/openshift/cluster-capi-operator/pkg/gc"
+	kubeconfiglib "github.com/openshift/cluster-capi-operator/pkg/kubeconfig"
+	"github.com/openshift/cluster-capi-operator/pkg/metrics"
+	"github.com/openshift/cluster-capi-operator/pkg/util/infrawatch"
+	reconcileutil "github.com/openshift/cluster-capi-operator/pkg/util/reconcile"
+	"github.com/openshift/cluster-capi-operator/pkg/util/resourceapply"
+)
+
+// controllerName is the label value this controller reports under in
+// operator self-metrics.
+const controllerName = "kubeconfig"
+
+const (
+	// secretNameSuffix is appended to a cluster's name to derive the name
+	// of its kubeconfig secret.
+	secretNameSuffix = "-capi-kubeconfig"
+
+	// compatibilitySecretNameSuffix names the Opaque-typed mirror of the
+	// kubeconfig secret published when PublishCompatibilitySecret is set.
+	compatibilitySecretNameSuffix = "-capi-kubeconfig-compat"
+
+	// capiSecretType is the Secret.Type Cluster API tooling expects its
+	// kubeconfig secrets to carry.
+	capiSecretType corev1.SecretType = "cluster.x-k8s.io/secret"
+
+	// secretDataKey is the Secret.Data key CAPI tooling reads the
+	// kubeconfig content from.
+	secretDataKey = "value"
+
+	// contentHashAnnotation records the hash of the kubeconfig content
+	// last seen by this controller, so a subsequent reconcile can tell
+	// whether the secret's content rotated since and report its impact.
+	contentHashAnnotation = "cluster-api.openshift.io/kubeconfig-content-hash"
+
+	// contentRotatedAtAnnotation records the RFC 3339 timestamp at which
+	// contentHashAnnotation was last updated, so a consumer auth failure
+	// can be correlated against the secret's current content instead of
+	// one it already rotated away from.
+	contentRotatedAtAnnotation = "cluster-api.openshift.io/kubeconfig-rotated-at"
+)
+
+// kubeconfigRotatedReason labels the Event emitted when a kubeconfig
+// secret's content changes and affected consumers are reported.
+const kubeconfigRotatedReason = "KubeconfigRotated"
+
+// tokenVerificationFailedReason labels the Event emitted when a
+// kubeconfig secret's embedded token fails verification.
+const tokenVerificationFailedReason = "TokenVerificationFailed"
+
+// endpointVerificationFailedReason labels the Event emitted when a
+// kubeconfig secret's embedded server endpoint fails the reachability
+// preflight.
+const endpointVerificationFailedReason = "EndpointVerificationFailed"
+
+// consumerAuthFailureReason labels the Event emitted when a kubeconfig
+// secret's consumer reports an authentication failure since the secret's
+// last rotation.
+const consumerAuthFailureReason = "KubeconfigConsumerAuthFailure"
+
+// clusterNameForSecret derives the owning cluster's name from a kubeconfig
+// secret's name. It is a pure function of its input so that concurrent
+// reconciles never need to coordinate through reconciler state: the
+// reconciler holds no per-cluster fields, only the shared client and
+// namespace it was constructed with.
+func clusterNameForSecret(secretName string) string {
+	return strings.TrimSuffix(secretName, secretNameSuffix)
+}
+
+// SecretNameForCluster returns the name of the kubeconfig secret this
+// controller publishes for the Cluster named clusterName, the inverse of
+// clusterNameForSecret. Exported for other controllers (e.g.
+// clusterinventory) that need to reference a Cluster's kubeconfig secret
+// by name without duplicating the naming convention.
+func SecretNameForCluster(clusterName string) string {
+	return clusterName + secretNameSuffix
+}
+
+// KubeconfigReconciler reconciles the capi-kubeconfig secret consumed by
+// Cluster API providers.
+type KubeconfigReconciler struct {
+	client.Client
+
+	// Namespace is the namespace the operator manages resources in.
+	Namespace string
+
+	// MaxConcurrentReconciles bounds how many kubeconfig secrets can be
+	// reconciled at once, allowing multiple managed clusters' kubeconfigs
+	// to be refreshed concurrently. Defaults to 1 if unset.
+	MaxConcurrentReconciles int
+
+	// PublishCompatibilitySecret, when set, maintains a second, Opaque-typed
+	// copy of each kubeconfig secret for consumers that cannot handle the
+	// cluster.x-k8s.io/secret type CAPI tooling expects.
+	PublishCompatibilitySecret bool
+
+	// Recorder emits events when a kubeconfig secret has to be deleted and
+	// recreated after an immutable Secret.Type conflict, or when an
+	// embedded token fails verification.
+	Recorder record.EventRecorder
+
+	// VerifyTokenBeforePublish, when set, performs a SelfSubjectReview
+	// against the secret's own embedded token before republishing it, so
+	// an invalid or expired token is caught and surfaced as an Event
+	// instead of silently reaching a provider.
+	VerifyTokenBeforePublish bool
+
+	// VerifyEndpointBeforePublish, when set, dials the secret's own
+	// embedded server URL and validates its certificate against the
+	// embedded CA before republishing, so an unreachable endpoint or a
+	// CA mismatch is caught and surfaced as an Event instead of
+	// silently reaching a provider.
+	VerifyEndpointBeforePublish bool
+
+	// ImmutableSecrets, when set, creates the kubeconfig secret (and its
+	// compatibility mirror, if PublishCompatibilitySecret is also set)
+	// with Immutable set, reducing kubelet watch overhead for consumers
+	// and making rotations an explicit recreate event rather than a
+	// silent in-place update. Any content change is handled by deleting
+	// and recreating the secret, the same immutable-field dance
+	// resourceapply.CreateOrRecreate already performs for Secret.Type.
+	ImmutableSecrets bool
+
+	// ForceRegenerateOnAuthFailure, when set, deletes the kubeconfig
+	// secret (triggering the same recreate path as a secret that was
+	// deleted out-of-band) once a consumer reports an authentication
+	// failure after the secret's last rotation, on the theory that the
+	// published credential itself is bad rather than merely not yet
+	// picked up by the consumer.
+	ForceRegenerateOnAuthFailure bool
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *KubeconfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	maxConcurrent := r.MaxConcurrentReconciles
+	if maxConcurrent <= 0 {
+		maxConcurrent = 1
+	}
+
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor("kubeconfig")
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Secret{}).
+		Watches(&configv1.Infrastructure{}, handler.EnqueueRequestsFromMapFunc(r.mapInfrastructureToSecrets),
+			builder.WithPredicates(infrawatch.RelevantChange())).
+		Named("kubeconfig").
+		WithOptions(controller.Options{MaxConcurrentReconciles: maxConcurrent}).
+		Complete(r)
+}
+
+// mapInfrastructureToSecrets requeues every managed kubeconfig secret when
+// the cluster Infrastructure object semantically changes (see
+// infrawatch.RelevantChange), since its API server URL and
+// certificate authority feed directly into each kubeconfig's content.
+func (r *KubeconfigReconciler) mapInfrastructureToSecrets(ctx context.Context, obj client.Object) []ctrl.Request {
+	secrets := &corev1.SecretList{}
+	if err := r.List(ctx, secrets, client.InNamespace(r.Namespace)); err != nil {
+		log.FromContext(ctx).Error(err, "failed to list kubeconfig secrets for Infrastructure watch")
+		return nil
+	}
+
+	requests := make([]ctrl.Request, 0, len(secrets.Items))
+	for _, secret := range secrets.Items {
+		if !strings.HasSuffix(secret.Name, secretNameSuffix) {
+			continue
+		}
+		requests = append(requests, ctrl.Request{
+			NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace},
+		})
+	}
+
+	return requests
+}
+
+// Reconcile ensures the capi-kubeconfig secret exists and is up to date in
+// the operator's managed namespace.
+func (r *KubeconfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, cancel := reconcileutil.WithTimeout(ctx)
+	defer cancel()
+
+	start := time.Now()
+	logger := log.FromContext(ctx)
+
+	if !strings.HasSuffix(req.Name, secretNameSuffix) {
+		return ctrl.Result{}, nil
+	}
+
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, req.NamespacedName, secret)
+	if apierrors.IsNotFound(err) {
+		logger.Info("kubeconfig secret not found, will be created on next sync")
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	clusterName := clusterNameForSecret(secret.Name)
+
+	inClusterAuth, err := r.inClusterAuthEnabled(ctx)
+	if err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+	if inClusterAuth {
+		logger.V(1).Info("skipping kubeconfig secret, in-cluster auth is enabled", "cluster", clusterName)
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+
+	if r.VerifyTokenBeforePublish {
+		if err := r.verifyEmbeddedToken(ctx, secret); err != nil {
+			r.Recorder.Eventf(secret, corev1.EventTypeWarning, tokenVerificationFailedReason,
+				"not republishing kubeconfig secret for cluster %s: %v", clusterName, err)
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+	}
+
+	if r.VerifyEndpointBeforePublish {
+		if err := r.verifyEmbeddedEndpoint(ctx, secret); err != nil {
+			r.Recorder.Eventf(secret, corev1.EventTypeWarning, endpointVerificationFailedReason,
+				"not republishing kubeconfig secret for cluster %s: %v", clusterName, err)
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+	}
+
+	// contentRotated mirrors the hash tracking reportConsumerImpact uses to
+	// detect rotation for event/metric purposes, so that once a secret is
+	// Immutable, a content change (e.g. a recreate performed out-of-band by
+	// whatever last wrote secret.Data) also routes through
+	// CreateOrRecreate here, instead of relying on a plain patch that the
+	// API server would reject outright for an immutable Secret.
+	previousHash := secret.Annotations[contentHashAnnotation]
+	contentRotated := r.ImmutableSecrets && previousHash != "" && gc.SourceHash(secret.Data[secretDataKey]) != previousHash
+
+	if secret.Type != capiSecretType || secret.Labels[gc.ClusterNameLabel] != clusterName || (r.ImmutableSecrets && !boolPtrTrue(secret.Immutable)) || contentRotated {
+		data := secret.Data
+		if _, err := resourceapply.CreateOrRecreate(ctx, controllerName, r.Client, r.Recorder, secret, func() error {
+			secret.Type = capiSecretType
+			secret.Data = data
+			gc.StampClusterName(secret, clusterName)
+			if r.ImmutableSecrets {
+				immutable := true
+				secret.Immutable = &immutable
+			}
+			return nil
+		}); err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+	}
+
+	if r.PublishCompatibilitySecret {
+		if err := r.ensureCompatibilitySecret(ctx, secret); err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+	}
+
+	if err := r.reportConsumerImpact(ctx, secret); err != nil {
+		logger.Error(err, "failed to report kubeconfig consumer impact", "cluster", clusterName)
+	}
+
+	logger.V(1).Info("reconciled kubeconfig secret", "cluster", clusterName)
+
+	metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+	return ctrl.Result{}, nil
+}
+
+// inClusterAuthEnabled reports whether any ClusterAPIInstallation in
+// Namespace has opted into InClusterAuth, meaning providers authenticate
+// via a projected ServiceAccount token volume (see
+// pkg/controllers/projectedtoken) instead of the kubeconfig secret this
+// controller would otherwise publish.
+func (r *KubeconfigReconciler) inClusterAuthEnabled(ctx context.Context) (bool, error) {
+	installations := &operatorv1alpha1.ClusterAPIInstallationList{}
+	if err := r.List(ctx, installations, client.InNamespace(r.Namespace)); err != nil {
+		return false, err
+	}
+
+	for _, installation := range installations.Items {
+		if installation.Spec.InClusterAuth {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// embeddedOptions parses secret's stored kubeconfig content and returns
+// its current-context server, CA, and token as kubeconfiglib.Options, so
+// both verifyEmbeddedToken and verifyEmbeddedEndpoint can validate what
+// is about to be republished without parsing the content twice.
+func embeddedOptions(secret *corev1.Secret) (kubeconfiglib.Options, bool, error) {
+	raw := secret.Data[secretDataKey]
+	if len(raw) == 0 {
+		return kubeconfiglib.Options{}, false, nil
+	}
+
+	config, err := clientcmd.Load(raw)
+	if err != nil {
+		return kubeconfiglib.Options{}, false, fmt.Errorf("failed to parse stored kubeconfig: %w", err)
+	}
+
+	kubeContext, ok := config.Contexts[config.CurrentContext]
+	if !ok {
+		return kubeconfiglib.Options{}, false, fmt.Errorf("stored kubeconfig has no current context %q", config.CurrentContext)
+	}
+	cluster, ok := config.Clusters[kubeContext.Cluster]
+	if !ok {
+		return kubeconfiglib.Options{}, false, fmt.Errorf("stored kubeconfig has no cluster %q", kubeContext.Cluster)
+	}
+	authInfo, ok := config.AuthInfos[kubeContext.AuthInfo]
+	if !ok {
+		return kubeconfiglib.Options{}, false, fmt.Errorf("stored kubeconfig has no user %q", kubeContext.AuthInfo)
+	}
+
+	return kubeconfiglib.Options{
+		Server:                   cluster.Server,
+		CertificateAuthorityData: cluster.CertificateAuthorityData,
+		Token:                    authInfo.Token,
+	}, true, nil
+}
+
+// verifyEmbeddedToken parses secret's stored kubeconfig content and
+// verifies its current-context token authenticates against its
+// current-context cluster, so a stale or revoked token is never
+// republished.
+func (r *KubeconfigReconciler) verifyEmbeddedToken(ctx context.Context, secret *corev1.Secret) error {
+	opts, ok, err := embeddedOptions(secret)
+	if err != nil || !ok {
+		return err
+	}
+
+	return kubeconfiglib.VerifyToken(ctx, opts)
+}
+
+// verifyEmbeddedEndpoint parses secret's stored kubeconfig content and
+// verifies its current-context server is reachable and serves a
+// certificate matching its current-context CA, so an unresolvable or
+// misconfigured endpoint is never republished.
+func (r *KubeconfigReconciler) verifyEmbeddedEndpoint(ctx context.Context, secret *corev1.Secret) error {
+	opts, ok, err := embeddedOptions(secret)
+	if err != nil || !ok {
+		return err
+	}
+
+	return kubeconfiglib.VerifyEndpoint(ctx, opts)
+}
+
+// reportConsumerImpact records how many Pods currently mount secret and
+// how many of them have reported an authentication failure since its last
+// rotation (see consumerAuthFailures); if ForceRegenerateOnAuthFailure is
+// set and any have, it deletes secret so it gets republished fresh. It
+// also emits an Event naming the affected consumers whenever secret's
+// content has changed since the last reconcile that observed it, so
+// rotation blast radius is visible without reaching for the secret's
+// consumers by hand.
+func (r *KubeconfigReconciler) reportConsumerImpact(ctx context.Context, secret *corev1.Secret) error {
+	consumers, err := consumingPods(ctx, r.Client, secret.Namespace, secret.Name)
+	if err != nil {
+		return err
+	}
+	metrics.SetKubeconfigConsumers(secret.Namespace, secret.Name, len(consumers))
+
+	rotatedAt := secret.CreationTimestamp.Time
+	if ts := secret.Annotations[contentRotatedAtAnnotation]; ts != "" {
+		if parsed, err := time.Parse(time.RFC3339, ts); err == nil {
+			rotatedAt = parsed
+		}
+	}
+
+	failures, err := consumerAuthFailures(ctx, r.Client, secret.Namespace, consumers, rotatedAt)
+	if err != nil {
+		return err
+	}
+	metrics.SetKubeconfigAuthFailures(secret.Namespace, secret.Name, len(failures))
+
+	if len(failures) > 0 {
+		r.Recorder.Eventf(secret, corev1.EventTypeWarning, consumerAuthFailureReason,
+			"%d consumer(s) reported an authentication failure since this kubeconfig was last rotated: %v", len(failures), failures)
+
+		if r.ForceRegenerateOnAuthFailure {
+			if err := r.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to delete kubeconfig secret for regeneration after consumer auth failures: %w", err)
+			}
+			return nil
+		}
+	}
+
+	currentHash := gc.SourceHash(secret.Data[secretDataKey])
+	previousHash := secret.Annotations[contentHashAnnotation]
+	if currentHash == previousHash {
+		return nil
+	}
+
+	if previousHash != "" && len(consumers) > 0 {
+		r.Recorder.Eventf(secret, corev1.EventTypeNormal, kubeconfigRotatedReason,
+			"kubeconfig content rotated, affecting %d consumer(s): %v", len(consumers), consumers)
+	}
+
+	_, err = resourceapply.CreateOrPatch(ctx, controllerName, r.Client, secret, func() error {
+		if secret.Annotations == nil {
+			secret.Annotations = map[string]string{}
+		}
+		secret.Annotations[contentHashAnnotation] = currentHash
+		secret.Annotations[contentRotatedAtAnnotation] = time.Now().Format(time.RFC3339)
+		return nil
+	})
+	return err
+}
+
+// AnyConsumerAuthFailure reports whether any kubeconfig secret in
+// namespace currently has a consumer reporting an authentication failure
+// since the secret's last rotation, for use as a
+// clusteroperator.ClusterOperatorReconciler.KubeconfigAuthFailureChecker.
+func AnyConsumerAuthFailure(ctx context.Context, c client.Client, namespace string) (bool, error) {
+	secrets := &corev1.SecretList{}
+	if err := c.List(ctx, secrets, client.InNamespace(namespace)); err != nil {
+		return false, fmt.Errorf("failed to list kubeconfig secrets to check consumer auth failures: %w", err)
+	}
+
+	for _, secret := range secrets.Items {
+		if !strings.HasSuffix(secret.Name, secretNameSuffix) {
+			continue
+		}
+
+		consumers, err := consumingPods(ctx, c, secret.Namespace, secret.Name)
+		if err != nil {
+			return false, err
+		}
+
+		rotatedAt := secret.CreationTimestamp.Time
+		if ts := secret.Annotations[contentRotatedAtAnnotation]; ts != "" {
+			if parsed, err := time.Parse(time.RFC3339, ts); err == nil {
+				rotatedAt = parsed
+			}
+		}
+
+		failures, err := consumerAuthFailures(ctx, c, secret.Namespace, consumers, rotatedAt)
+		if err != nil {
+			return false, err
+		}
+		if len(failures) > 0 {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// ensureCompatibilitySecret maintains an Opaque-typed mirror of secret
+// under compatibilitySecretNameSuffix, for consumers that cannot consume
+// the cluster.x-k8s.io/secret type.
+func (r *KubeconfigReconciler) ensureCompatibilitySecret(ctx context.Context, secret *corev1.Secret) error {
+	compat := &corev1.Secret{}
+	compat.Name = clusterNameForSecret(secret.Name) + compatibilitySecretNameSuffix
+	compat.Namespace = secret.Namespace
+
+	if !r.ImmutableSecrets {
+		_, err := controllerutil.CreateOrPatch(ctx, r.Client, compat, func() error {
+			compat.Type = corev1.SecretTypeOpaque
+			compat.Data = secret.Data
+			gc.StampClusterName(compat, clusterNameForSecret(secret.Name))
+			return nil
+		})
+		return err
+	}
+
+	_, err := resourceapply.CreateOrRecreate(ctx, controllerName, r.Client, r.Recorder, compat, func() error {
+		compat.Type = corev1.SecretTypeOpaque
+		compat.Data = secret.Data
+		gc.StampClusterName(compat, clusterNameForSecret(secret.Name))
+		immutable := true
+		compat.Immutable = &immutable
+		return nil
+	})
+	return err
+}
+
+// boolPtrTrue reports whether b is non-nil and true, treating a nil
+// Secret.Immutable (the API server's default) the same as explicit false.
+func boolPtrTrue(b *bool) bool {
+	return b != nil && *b
+}
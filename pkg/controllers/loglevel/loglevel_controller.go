@@ -0,0 +1,92 @@
+// Package loglevel reconciles the verbosity requested on a
+// ClusterAPIInstallation into every managed provider Deployment's
+// container args, so changing log level for debugging doesn't require a
+// manual deployment edit the operator would otherwise revert on its next
+// reconcile.
+package loglevel
+
+import (
+	"context"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	operatorv1alpha1 "github.com/openshift/cluster-capi-operator/api/v1alpha1"
+	"github.com/openshift/cluster-capi-operator/pkg/gc"
+	"github.com/openshift/cluster-capi-operator/pkg/metrics"
+	"github.com/openshift/cluster-capi-operator/pkg/providers"
+	"github.com/openshift/cluster-capi-operator/pkg/util/resourceapply"
+)
+
+// controllerName is the label value this controller reports under in
+// operator self-metrics.
+const controllerName = "loglevel"
+
+// Reconciler reconciles a ClusterAPIInstallation's LogLevel into every
+// operator-managed provider Deployment in Namespace.
+type Reconciler struct {
+	client.Client
+
+	// Namespace is the namespace the operator manages provider Deployments
+	// in.
+	Namespace string
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&operatorv1alpha1.ClusterAPIInstallation{}).
+		Named(controllerName).
+		Complete(r)
+}
+
+// Reconcile patches the requested verbosity into every container's args on
+// every operator-managed provider Deployment.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	start := time.Now()
+	logger := log.FromContext(ctx)
+
+	installation := &operatorv1alpha1.ClusterAPIInstallation{}
+	err := r.Get(ctx, req.NamespacedName, installation)
+	if apierrors.IsNotFound(err) {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, client.InNamespace(r.Namespace)); err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		if deployment.Labels[gc.ManagedByLabel] != gc.ManagedByValue {
+			continue
+		}
+
+		if _, err := resourceapply.CreateOrPatch(ctx, controllerName, r.Client, deployment, func() error {
+			containers := deployment.Spec.Template.Spec.Containers
+			for i := range containers {
+				containers[i].Args = providers.SetVerbosityArg(containers[i].Args, installation.Spec.LogLevel)
+			}
+			gc.StampManagedFields(deployment, "/spec/template/spec/containers/args")
+			return nil
+		}); err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+	}
+
+	logger.V(1).Info("synced provider verbosity", "level", installation.Spec.LogLevel)
+	metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,484 @@
+// Package machinesetsync mirrors MachineSets between machine-api and
+// Cluster API using pkg/conversion/mapi2capi and pkg/conversion/capi2mapi,
+// in whichever direction pkg/conversion.AuthoritativeAPIFor designates,
+// and records the outcome of each conversion on a MachineSetSync status
+// object, so the conversion libraries in pkg/conversion actually run
+// against a live cluster instead of sitting unused behind their tests.
+package machinesetsync
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	capav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	operatorv1alpha1 "github.com/openshift/cluster-capi-operator/api/v1alpha1"
+	"github.com/openshift/cluster-capi-operator/pkg/conversion"
+	"github.com/openshift/cluster-capi-operator/pkg/conversion/capi2mapi"
+	capi2mapiaws "github.com/openshift/cluster-capi-operator/pkg/conversion/capi2mapi/aws"
+	"github.com/openshift/cluster-capi-operator/pkg/conversion/mapi2capi"
+	mapi2capiaws "github.com/openshift/cluster-capi-operator/pkg/conversion/mapi2capi/aws"
+	"github.com/openshift/cluster-capi-operator/pkg/conversion/replicaauthority"
+	"github.com/openshift/cluster-capi-operator/pkg/gc"
+	"github.com/openshift/cluster-capi-operator/pkg/metrics"
+	"github.com/openshift/cluster-capi-operator/pkg/util/platform"
+	"github.com/openshift/cluster-capi-operator/pkg/util/resourceapply"
+)
+
+// controllerName is the label value this controller reports under in
+// operator self-metrics.
+const controllerName = "machinesetsync"
+
+// synchronizedConditionType is the MachineSetSync condition recording
+// whether the last conversion attempt for a MachineSet succeeded.
+const synchronizedConditionType = "Synchronized"
+
+// replicaAuthorityConflictConditionType is the MachineSetSync condition
+// recording whether the MAPI and CAPI sides of the pair currently disagree
+// about which one is authoritative for Spec.Replicas (see
+// pkg/conversion/replicaauthority), rather than one side simply not having
+// set the annotation yet.
+const replicaAuthorityConflictConditionType = "ReplicaAuthorityConflict"
+
+// awsMachineTemplateGVK identifies the AWSMachineTemplate objects this
+// controller maintains as the InfrastructureRef target of converted CAPI
+// MachineSets.
+var awsMachineTemplateGVK = capav1.GroupVersion.WithKind("AWSMachineTemplate")
+
+// platformStatusWaiter is satisfied by platform.StatusProvider. It lets
+// Reconciler block until the cluster's platform.Context is resolved, since
+// the CAPI cluster name a converted MachineSet is stamped with is derived
+// from the Infrastructure object's InfrastructureName rather than being
+// configured separately.
+type platformStatusWaiter interface {
+	Wait(ctx context.Context) (platform.Context, error)
+}
+
+// Reconciler mirrors MachineSets between MAPINamespace (machine-api) and
+// Namespace (Cluster API), converting whichever side is authoritative for
+// a pair (see pkg/conversion.AuthoritativeAPIFor) into the other, and
+// leaving a pair mid-migration untouched on both sides.
+type Reconciler struct {
+	client.Client
+
+	// Namespace is the namespace the operator manages CAPI resources in.
+	Namespace string
+
+	// MAPINamespace is the namespace machine-api MachineSets live in.
+	// Defaults to "openshift-machine-api" if unset.
+	MAPINamespace string
+
+	// PlatformStatus is waited on for the CAPI Cluster name (the cluster's
+	// InfrastructureName) before the first conversion.
+	PlatformStatus platformStatusWaiter
+}
+
+// SetupWithManager sets up the controller with the Manager. It watches CAPI
+// MachineSets in addition to MAPI ones, mapped back onto the MAPI
+// namespace/name the pair is keyed by, so a rollback (AuthoritativeAPI
+// switched back to Cluster API) is picked up from an edit to either side.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		Named(controllerName).
+		For(&machinev1beta1.MachineSet{}).
+		Watches(&clusterv1.MachineSet{}, handler.EnqueueRequestsFromMapFunc(r.capiMachineSetToRequest)).
+		Complete(r)
+}
+
+// capiMachineSetToRequest maps a CAPI MachineSet event onto the reconcile
+// Request for the MAPI namespace/name its pair is keyed by.
+func (r *Reconciler) capiMachineSetToRequest(_ context.Context, obj client.Object) []reconcile.Request {
+	capiMachineSet, ok := obj.(*clusterv1.MachineSet)
+	if !ok {
+		return nil
+	}
+	return []reconcile.Request{{NamespacedName: types.NamespacedName{Namespace: r.mapiNamespace(), Name: capiMachineSet.Name}}}
+}
+
+// mapiNamespace returns the namespace machine-api MachineSets live in,
+// defaulting to "openshift-machine-api" when MAPINamespace is unset.
+func (r *Reconciler) mapiNamespace() string {
+	if r.MAPINamespace != "" {
+		return r.MAPINamespace
+	}
+	return "openshift-machine-api"
+}
+
+// Reconcile fetches both sides of the MachineSet pair named by req and
+// converts whichever side is authoritative (see
+// pkg/conversion.AuthoritativeAPIFor) into the other, recording the
+// outcome on a MachineSetSync object of the same name. A pair mid-migration
+// is left alone on both sides until the switch completes.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	start := time.Now()
+	logger := log.FromContext(ctx)
+
+	if req.Namespace != r.mapiNamespace() {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+
+	mapiMachineSet := &machinev1beta1.MachineSet{}
+	if err := r.Get(ctx, req.NamespacedName, mapiMachineSet); err != nil {
+		if !apierrors.IsNotFound(err) {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+		mapiMachineSet = nil
+	}
+
+	capiMachineSet := &clusterv1.MachineSet{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: r.Namespace, Name: req.Name}, capiMachineSet); err != nil {
+		if !apierrors.IsNotFound(err) {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+		capiMachineSet = nil
+	}
+
+	if mapiMachineSet == nil && capiMachineSet == nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+
+	var annotations map[string]string
+	switch {
+	case mapiMachineSet != nil:
+		annotations = mapiMachineSet.Annotations
+	case capiMachineSet != nil:
+		annotations = capiMachineSet.Annotations
+	}
+
+	switch conversion.AuthoritativeAPIFor(annotations) {
+	case conversion.AuthoritativeAPIClusterAPI:
+		if capiMachineSet == nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+			return ctrl.Result{}, nil
+		}
+		if err := r.syncToMAPI(ctx, capiMachineSet); err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+	case conversion.AuthoritativeAPIMigrating:
+		logger.V(1).Info("skipping sync, MachineSet is mid-migration", "machineSet", req.Name)
+	default:
+		if mapiMachineSet == nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+			return ctrl.Result{}, nil
+		}
+		platformCtx, err := r.PlatformStatus.Wait(ctx)
+		if err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+		if err := r.syncToCAPI(ctx, mapiMachineSet, platformCtx.InfrastructureName); err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+	}
+
+	metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+	return ctrl.Result{}, nil
+}
+
+// syncToCAPI converts mapiMachineSet and applies the result as its CAPI
+// mirror, recording the outcome on the matching MachineSetSync object
+// whether conversion succeeds or fails.
+func (r *Reconciler) syncToCAPI(ctx context.Context, mapiMachineSet *machinev1beta1.MachineSet, clusterName string) error {
+	infraRef, unsupported, err := r.syncInfrastructureTemplate(ctx, mapiMachineSet)
+	if err != nil {
+		return r.recordSyncResult(ctx, mapiMachineSet, "", unsupported, false, fmt.Errorf("failed to sync infrastructure template: %w", err))
+	}
+
+	var existingCAPIReplicas *int32
+	existingCAPIMachineSet := &clusterv1.MachineSet{}
+	key := types.NamespacedName{Namespace: r.Namespace, Name: mapiMachineSet.Name}
+	if err := r.Get(ctx, key, existingCAPIMachineSet); err == nil {
+		existingCAPIReplicas = existingCAPIMachineSet.Spec.Replicas
+	} else if !apierrors.IsNotFound(err) {
+		return r.recordSyncResult(ctx, mapiMachineSet, "", unsupported, false, fmt.Errorf("failed to read existing CAPI MachineSet: %w", err))
+	}
+
+	conflict := replicaAuthorityConflict(mapiMachineSet.Annotations, existingCAPIMachineSet.Annotations)
+	if conflict {
+		log.FromContext(ctx).Info("MAPI and CAPI sides disagree on replica authority", "machineSet", mapiMachineSet.Name)
+		metrics.ObserveReplicaAuthorityConflict(r.Namespace, mapiMachineSet.Name)
+	}
+
+	desired := mapi2capi.ToMachineSet(mapiMachineSet, clusterName, infraRef, existingCAPIReplicas)
+
+	capiMachineSet := &clusterv1.MachineSet{}
+	capiMachineSet.Name = mapiMachineSet.Name
+	capiMachineSet.Namespace = r.Namespace
+	if _, err := resourceapply.CreateOrPatch(ctx, controllerName, r.Client, capiMachineSet, func() error {
+		capiMachineSet.Labels = desired.Labels
+		if capiMachineSet.Labels == nil {
+			capiMachineSet.Labels = map[string]string{}
+		}
+		capiMachineSet.Labels[gc.ManagedByLabel] = gc.ManagedByValue
+		capiMachineSet.Annotations = desired.Annotations
+		capiMachineSet.Spec = desired.Spec
+		return nil
+	}); err != nil {
+		return r.recordSyncResult(ctx, mapiMachineSet, "", unsupported, conflict, fmt.Errorf("failed to apply CAPI MachineSet: %w", err))
+	}
+
+	return r.recordSyncResult(ctx, mapiMachineSet, capiMachineSet.Name, unsupported, conflict, nil)
+}
+
+// replicaAuthorityConflict reports whether mapiAnnotations and
+// capiAnnotations carry disagreeing non-empty replicaauthority.Annotation
+// values, meaning an operator set conflicting intent directly on both
+// sides of the pair instead of flipping authority once and letting it
+// carry over via conversion.
+func replicaAuthorityConflict(mapiAnnotations, capiAnnotations map[string]string) bool {
+	mapiAuthority := mapiAnnotations[replicaauthority.Annotation]
+	capiAuthority := capiAnnotations[replicaauthority.Annotation]
+	return mapiAuthority != "" && capiAuthority != "" && mapiAuthority != capiAuthority
+}
+
+// syncInfrastructureTemplate decodes mapiMachineSet's AWS provider spec,
+// converts it, and applies the result as an AWSMachineTemplate, returning
+// an InfrastructureRef pointing at it for use on the converted CAPI
+// MachineSet's machine template. It also returns the fields
+// mapi2capiaws.ToAWSMachineSpec could not represent, if any, alongside any
+// error.
+func (r *Reconciler) syncInfrastructureTemplate(ctx context.Context, mapiMachineSet *machinev1beta1.MachineSet) (corev1.ObjectReference, []string, error) {
+	infraRef := corev1.ObjectReference{
+		APIVersion: awsMachineTemplateGVK.GroupVersion().String(),
+		Kind:       awsMachineTemplateGVK.Kind,
+		Name:       mapiMachineSet.Name,
+		Namespace:  r.Namespace,
+	}
+
+	raw := mapiMachineSet.Spec.Template.Spec.ProviderSpec.Value
+	if raw == nil || len(raw.Raw) == 0 {
+		return infraRef, nil, fmt.Errorf("MachineSet %s has no provider spec", mapiMachineSet.Name)
+	}
+
+	var providerConfig machinev1beta1.AWSMachineProviderConfig
+	if err := json.Unmarshal(raw.Raw, &providerConfig); err != nil {
+		return infraRef, nil, fmt.Errorf("failed to decode provider spec: %w", err)
+	}
+
+	awsSpec, err := mapi2capiaws.ToAWSMachineSpec(&providerConfig)
+	if err != nil {
+		return infraRef, []string{err.Error()}, err
+	}
+
+	template := &capav1.AWSMachineTemplate{}
+	template.Name = mapiMachineSet.Name
+	template.Namespace = r.Namespace
+	if _, err := resourceapply.CreateOrPatch(ctx, controllerName, r.Client, template, func() error {
+		if template.Labels == nil {
+			template.Labels = map[string]string{}
+		}
+		template.Labels[gc.ManagedByLabel] = gc.ManagedByValue
+		template.Spec.Template.Spec = *awsSpec
+		return nil
+	}); err != nil {
+		return infraRef, nil, fmt.Errorf("failed to apply AWSMachineTemplate: %w", err)
+	}
+
+	return infraRef, nil, nil
+}
+
+// syncToMAPI converts capiMachineSet and applies the result as its MAPI
+// mirror, the reverse of syncToCAPI, used to roll a MachineSet back from
+// Cluster API to machine-api management. It records the outcome on the
+// matching MachineSetSync object whether conversion succeeds or fails.
+func (r *Reconciler) syncToMAPI(ctx context.Context, capiMachineSet *clusterv1.MachineSet) error {
+	providerSpecValue, unsupported, err := r.buildMAPIProviderSpec(ctx, capiMachineSet)
+	if err != nil {
+		return r.recordReverseSyncResult(ctx, capiMachineSet, unsupported, false, fmt.Errorf("failed to build provider spec: %w", err))
+	}
+
+	mapiNamespace := r.mapiNamespace()
+	var existingMAPIReplicas *int32
+	existingMAPIMachineSet := &machinev1beta1.MachineSet{}
+	key := types.NamespacedName{Namespace: mapiNamespace, Name: capiMachineSet.Name}
+	if err := r.Get(ctx, key, existingMAPIMachineSet); err == nil {
+		existingMAPIReplicas = existingMAPIMachineSet.Spec.Replicas
+	} else if !apierrors.IsNotFound(err) {
+		return r.recordReverseSyncResult(ctx, capiMachineSet, unsupported, false, fmt.Errorf("failed to read existing MAPI MachineSet: %w", err))
+	}
+
+	conflict := replicaAuthorityConflict(existingMAPIMachineSet.Annotations, capiMachineSet.Annotations)
+	if conflict {
+		log.FromContext(ctx).Info("MAPI and CAPI sides disagree on replica authority", "machineSet", capiMachineSet.Name)
+		metrics.ObserveReplicaAuthorityConflict(mapiNamespace, capiMachineSet.Name)
+	}
+
+	desired := capi2mapi.ToMachineSet(capiMachineSet, providerSpecValue, existingMAPIReplicas)
+
+	mapiMachineSet := &machinev1beta1.MachineSet{}
+	mapiMachineSet.Name = capiMachineSet.Name
+	mapiMachineSet.Namespace = mapiNamespace
+	if _, err := resourceapply.CreateOrPatch(ctx, controllerName, r.Client, mapiMachineSet, func() error {
+		mapiMachineSet.Labels = desired.Labels
+		if mapiMachineSet.Labels == nil {
+			mapiMachineSet.Labels = map[string]string{}
+		}
+		mapiMachineSet.Labels[gc.ManagedByLabel] = gc.ManagedByValue
+		mapiMachineSet.Annotations = desired.Annotations
+		mapiMachineSet.Spec = desired.Spec
+		return nil
+	}); err != nil {
+		return r.recordReverseSyncResult(ctx, capiMachineSet, unsupported, conflict, fmt.Errorf("failed to apply MAPI MachineSet: %w", err))
+	}
+
+	return r.recordReverseSyncResult(ctx, capiMachineSet, unsupported, conflict, nil)
+}
+
+// buildMAPIProviderSpec reads the AWSMachineTemplate capiMachineSet's
+// InfrastructureRef points at and converts its AWSMachineSpec into a raw
+// MAPI AWSMachineProviderConfig, the provider-specific payload
+// capi2mapi.ToMachineSet needs but cannot derive from InfrastructureRef on
+// its own. It also returns the fields
+// capi2mapiaws.ToAWSMachineProviderConfig could not represent, if any,
+// alongside any error.
+func (r *Reconciler) buildMAPIProviderSpec(ctx context.Context, capiMachineSet *clusterv1.MachineSet) ([]byte, []string, error) {
+	infraRef := capiMachineSet.Spec.Template.Spec.InfrastructureRef
+	if infraRef.Kind != awsMachineTemplateGVK.Kind {
+		err := fmt.Errorf("unsupported InfrastructureRef kind: %q", infraRef.Kind)
+		return nil, []string{err.Error()}, err
+	}
+
+	template := &capav1.AWSMachineTemplate{}
+	key := types.NamespacedName{Namespace: r.Namespace, Name: infraRef.Name}
+	if err := r.Get(ctx, key, template); err != nil {
+		return nil, nil, fmt.Errorf("failed to get AWSMachineTemplate %s: %w", infraRef.Name, err)
+	}
+
+	providerConfig := capi2mapiaws.ToAWSMachineProviderConfig(&template.Spec.Template.Spec)
+
+	raw, err := json.Marshal(providerConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to encode provider spec: %w", err)
+	}
+
+	return raw, nil, nil
+}
+
+// recordSyncResult applies the MachineSetSync status object for
+// mapiMachineSet, reporting syncErr (if any), unsupported (the fields the
+// conversion could not represent, if any), and replicaAuthorityConflict
+// (whether the pair currently disagrees about which side owns
+// Spec.Replicas) on its Synchronized and ReplicaAuthorityConflict
+// conditions. It returns syncErr unchanged so callers can propagate it
+// after the status write.
+func (r *Reconciler) recordSyncResult(ctx context.Context, mapiMachineSet *machinev1beta1.MachineSet, capiMachineSetName string, unsupported []string, hasReplicaAuthorityConflict bool, syncErr error) error {
+	sync := &operatorv1alpha1.MachineSetSync{}
+	sync.Name = mapiMachineSet.Name
+	sync.Namespace = r.Namespace
+
+	_, err := resourceapply.CreateOrPatch(ctx, controllerName, r.Client, sync, func() error {
+		sync.Spec.MAPIMachineSetName = mapiMachineSet.Name
+		if capiMachineSetName != "" {
+			sync.Status.CAPIMachineSetName = capiMachineSetName
+		}
+		sync.Status.UnsupportedFields = unsupported
+		now := metav1.Now()
+		sync.Status.LastSyncTime = &now
+
+		condition := metav1.Condition{
+			Type:               synchronizedConditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             "ConversionSucceeded",
+			Message:            "the MAPI MachineSet was successfully converted to its CAPI equivalent",
+			ObservedGeneration: mapiMachineSet.Generation,
+		}
+		if syncErr != nil {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "ConversionFailed"
+			condition.Message = syncErr.Error()
+		}
+		apimeta.SetStatusCondition(&sync.Status.Conditions, condition)
+
+		conflictCondition := metav1.Condition{
+			Type:               replicaAuthorityConflictConditionType,
+			Status:             metav1.ConditionFalse,
+			Reason:             "AuthorityAgreed",
+			Message:            "the MAPI and CAPI sides of this pair agree on which one is authoritative for Spec.Replicas",
+			ObservedGeneration: mapiMachineSet.Generation,
+		}
+		if hasReplicaAuthorityConflict {
+			conflictCondition.Status = metav1.ConditionTrue
+			conflictCondition.Reason = "AuthorityDisputed"
+			conflictCondition.Message = "the MAPI and CAPI MachineSets carry disagreeing replica-authority annotations"
+		}
+		apimeta.SetStatusCondition(&sync.Status.Conditions, conflictCondition)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return syncErr
+}
+
+// recordReverseSyncResult applies the MachineSetSync status object for
+// capiMachineSet, reporting the outcome of converting it back into its
+// MAPI equivalent (see syncToMAPI). It mirrors recordSyncResult for the
+// reverse direction.
+func (r *Reconciler) recordReverseSyncResult(ctx context.Context, capiMachineSet *clusterv1.MachineSet, unsupported []string, hasReplicaAuthorityConflict bool, syncErr error) error {
+	sync := &operatorv1alpha1.MachineSetSync{}
+	sync.Name = capiMachineSet.Name
+	sync.Namespace = r.Namespace
+
+	_, err := resourceapply.CreateOrPatch(ctx, controllerName, r.Client, sync, func() error {
+		sync.Spec.MAPIMachineSetName = capiMachineSet.Name
+		sync.Status.CAPIMachineSetName = capiMachineSet.Name
+		sync.Status.UnsupportedFields = unsupported
+		now := metav1.Now()
+		sync.Status.LastSyncTime = &now
+
+		condition := metav1.Condition{
+			Type:               synchronizedConditionType,
+			Status:             metav1.ConditionTrue,
+			Reason:             "ConversionSucceeded",
+			Message:            "the CAPI MachineSet was successfully converted back to its MAPI equivalent",
+			ObservedGeneration: capiMachineSet.Generation,
+		}
+		if syncErr != nil {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "ConversionFailed"
+			condition.Message = syncErr.Error()
+		}
+		apimeta.SetStatusCondition(&sync.Status.Conditions, condition)
+
+		conflictCondition := metav1.Condition{
+			Type:               replicaAuthorityConflictConditionType,
+			Status:             metav1.ConditionFalse,
+			Reason:             "AuthorityAgreed",
+			Message:            "the MAPI and CAPI sides of this pair agree on which one is authoritative for Spec.Replicas",
+			ObservedGeneration: capiMachineSet.Generation,
+		}
+		if hasReplicaAuthorityConflict {
+			conflictCondition.Status = metav1.ConditionTrue
+			conflictCondition.Reason = "AuthorityDisputed"
+			conflictCondition.Message = "the MAPI and CAPI MachineSets carry disagreeing replica-authority annotations"
+		}
+		apimeta.SetStatusCondition(&sync.Status.Conditions, conflictCondition)
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	return syncErr
+}
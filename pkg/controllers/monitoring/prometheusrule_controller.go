@@ -0,0 +1,166 @@
+// Package monitoring manages the PrometheusRule object that ships the
+// operator's alerting rules, so that cluster admins get actionable alerts
+// out of the box without hand-authoring them.
+package monitoring
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// prometheusRuleGVK identifies the monitoring.coreos.com PrometheusRule CRD
+// that this controller reconciles against.
+var prometheusRuleGVK = schema.GroupVersionKind{
+	Group:   "monitoring.coreos.com",
+	Version: "v1",
+	Kind:    "PrometheusRule",
+}
+
+const (
+	// RuleName is the name of the PrometheusRule managed by this controller.
+	RuleName = "cluster-api-operator-rules"
+)
+
+// PrometheusRuleReconciler creates and updates the PrometheusRule carrying
+// the operator's alerting rules. It is a no-op when the monitoring CRDs are
+// not installed on the cluster.
+type PrometheusRuleReconciler struct {
+	client.Client
+
+	// Namespace is the monitoring namespace the PrometheusRule is created in.
+	Namespace string
+
+	// RESTMapper is used to detect whether the monitoring CRDs are present.
+	RESTMapper meta.RESTMapper
+}
+
+// SetupWithManager sets up the controller with the Manager. Reconciliation
+// is triggered on a periodic basis rather than by watches, since the
+// PrometheusRule CRD may not exist when the manager starts.
+func (r *PrometheusRuleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.RESTMapper == nil {
+		r.RESTMapper = mgr.GetRESTMapper()
+	}
+	return ctrl.NewControllerManagedBy(mgr).
+		Named("prometheusrule").
+		Complete(r)
+}
+
+// Reconcile creates or updates the PrometheusRule object. If the monitoring
+// CRDs are not installed, it logs and returns without error so the operator
+// does not degrade on clusters without monitoring.
+func (r *PrometheusRuleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	if !r.monitoringCRDPresent() {
+		logger.V(2).Info("monitoring CRDs not present, skipping PrometheusRule reconciliation")
+		return ctrl.Result{}, nil
+	}
+
+	rule := &unstructured.Unstructured{}
+	rule.SetGroupVersionKind(prometheusRuleGVK)
+	rule.SetName(RuleName)
+	rule.SetNamespace(r.Namespace)
+
+	_, err := controllerutil.CreateOrPatch(ctx, r.Client, rule, func() error {
+		groups := append(alertingRuleGroups(), telemetryRuleGroups()...)
+		return unstructured.SetNestedField(rule.Object, groups, "spec", "groups")
+	})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("failed to reconcile PrometheusRule %s/%s: %w", r.Namespace, RuleName, err)
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// monitoringCRDPresent reports whether the PrometheusRule CRD is registered
+// with the API server.
+func (r *PrometheusRuleReconciler) monitoringCRDPresent() bool {
+	_, err := r.RESTMapper.RESTMapping(prometheusRuleGVK.GroupKind(), prometheusRuleGVK.Version)
+	return err == nil
+}
+
+// telemetryRuleGroups returns the groups of recording rules shipped by the
+// operator, named with the "_:telemetry:" marker the Telemeter allowlist
+// uses to select which cluster-level series get reported upstream, so the
+// product team can measure Cluster API adoption across the fleet without
+// exporting raw per-cluster machine data.
+func telemetryRuleGroups() []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"name": "cluster-api-operator.telemetry",
+			"rules": []interface{}{
+				map[string]interface{}{
+					"record": "cluster:capi_machines:sum",
+					"expr":   `sum(capi_machines_total)`,
+				},
+				map[string]interface{}{
+					"record": "cluster:capi_machine_sets:sum",
+					"expr":   `sum(capi_machine_sets_total)`,
+				},
+				map[string]interface{}{
+					"record": "cluster:capi_providers_installed:sum",
+					"expr":   `sum(capi_providers_installed)`,
+				},
+				map[string]interface{}{
+					"record": "cluster:capi_migration_state:sum",
+					"expr":   `sum by (authoritative_api) (capi_migration_state_total)`,
+				},
+			},
+		},
+	}
+}
+
+// alertingRuleGroups returns the groups of alerting rules shipped by the
+// operator.
+func alertingRuleGroups() []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"name": "cluster-api-operator.rules",
+			"rules": []interface{}{
+				map[string]interface{}{
+					"alert": "ClusterCAPIOperatorDegraded",
+					"expr":  `cluster_operator_conditions{name="cluster-api", condition="Degraded"} == 1`,
+					"for":   "10m",
+					"labels": map[string]interface{}{
+						"severity": "warning",
+					},
+					"annotations": map[string]interface{}{
+						"summary": "The cluster-api ClusterOperator has been Degraded for more than 10 minutes.",
+					},
+				},
+				map[string]interface{}{
+					"alert": "CAPIKubeconfigSecretStale",
+					"expr":  `time() - capi_kubeconfig_secret_last_updated_seconds > 3600`,
+					"for":   "5m",
+					"labels": map[string]interface{}{
+						"severity": "warning",
+					},
+					"annotations": map[string]interface{}{
+						"summary": "The capi-kubeconfig secret has not been refreshed in over an hour.",
+					},
+				},
+				map[string]interface{}{
+					"alert": "CAPIProviderDown",
+					"expr":  `kube_deployment_status_replicas_available{namespace="openshift-cluster-api"} == 0`,
+					"for":   "10m",
+					"labels": map[string]interface{}{
+						"severity": "critical",
+					},
+					"annotations": map[string]interface{}{
+						"summary": "A Cluster API provider deployment has zero available replicas.",
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,157 @@
+// Package projectedtoken mounts a projected ServiceAccount token volume
+// on every operator-managed provider Deployment when a
+// ClusterAPIInstallation opts into InClusterAuth, so a provider running
+// on the management cluster it authenticates to can rely on its Pod
+// identity and in-cluster config auto-discovery instead of an embedded
+// kubeconfig secret (see pkg/controllers/kubeconfig).
+package projectedtoken
+
+import (
+	"context"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	operatorv1alpha1 "github.com/openshift/cluster-capi-operator/api/v1alpha1"
+	"github.com/openshift/cluster-capi-operator/pkg/gc"
+	"github.com/openshift/cluster-capi-operator/pkg/metrics"
+	"github.com/openshift/cluster-capi-operator/pkg/util/resourceapply"
+)
+
+// controllerName is the label value this controller reports under in
+// operator self-metrics.
+const controllerName = "projectedtoken"
+
+// volumeName is the name of the projected volume this controller
+// maintains on every managed Deployment.
+const volumeName = "in-cluster-token"
+
+// mountPath matches where client-go's in-cluster config auto-discovery
+// (rest.InClusterConfig) expects to find a ServiceAccount token, so
+// providers built against the standard client-go path need no extra
+// configuration to pick it up.
+const mountPath = "/var/run/secrets/kubernetes.io/serviceaccount"
+
+// tokenExpirationSeconds bounds how long each projected token is valid
+// for before kubelet rotates it, limiting the blast radius of a leaked
+// token compared to the non-expiring tokens Kubernetes auto-created
+// before 1.24.
+const tokenExpirationSeconds int64 = 3600
+
+// Reconciler mounts volumeName on every operator-managed provider
+// Deployment in Namespace whenever a ClusterAPIInstallation there has
+// InClusterAuth set.
+type Reconciler struct {
+	client.Client
+
+	// Namespace is the namespace the operator manages provider Deployments
+	// in.
+	Namespace string
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&operatorv1alpha1.ClusterAPIInstallation{}).
+		Named(controllerName).
+		Complete(r)
+}
+
+// Reconcile mounts or removes the projected token volume on every
+// operator-managed provider Deployment in Namespace, matching the
+// requesting ClusterAPIInstallation's InClusterAuth setting.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	start := time.Now()
+	logger := log.FromContext(ctx)
+
+	installation := &operatorv1alpha1.ClusterAPIInstallation{}
+	err := r.Get(ctx, req.NamespacedName, installation)
+	if apierrors.IsNotFound(err) {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	deployments := &appsv1.DeploymentList{}
+	if err := r.List(ctx, deployments, client.InNamespace(r.Namespace)); err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		if deployment.Labels[gc.ManagedByLabel] != gc.ManagedByValue {
+			continue
+		}
+
+		if _, err := resourceapply.CreateOrPatch(ctx, controllerName, r.Client, deployment, func() error {
+			applyProjectedToken(deployment, installation.Spec.InClusterAuth)
+			gc.StampManagedFields(deployment, "/spec/template/spec/volumes", "/spec/template/spec/containers/volumeMounts")
+			return nil
+		}); err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+	}
+
+	logger.V(1).Info("synced in-cluster auth volumes", "enabled", installation.Spec.InClusterAuth)
+	metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+	return ctrl.Result{}, nil
+}
+
+// applyProjectedToken adds or removes volumeName and its mount on every
+// container in deployment's pod template, depending on enabled.
+func applyProjectedToken(deployment *appsv1.Deployment, enabled bool) {
+	podSpec := &deployment.Spec.Template.Spec
+
+	volumes := make([]corev1.Volume, 0, len(podSpec.Volumes))
+	for _, volume := range podSpec.Volumes {
+		if volume.Name != volumeName {
+			volumes = append(volumes, volume)
+		}
+	}
+	if enabled {
+		volumes = append(volumes, corev1.Volume{
+			Name: volumeName,
+			VolumeSource: corev1.VolumeSource{
+				Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{
+						{
+							ServiceAccountToken: &corev1.ServiceAccountTokenProjection{
+								ExpirationSeconds: &tokenExpirationSeconds,
+							},
+						},
+					},
+				},
+			},
+		})
+	}
+	podSpec.Volumes = volumes
+
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+
+		mounts := make([]corev1.VolumeMount, 0, len(container.VolumeMounts))
+		for _, mount := range container.VolumeMounts {
+			if mount.Name != volumeName {
+				mounts = append(mounts, mount)
+			}
+		}
+		if enabled {
+			mounts = append(mounts, corev1.VolumeMount{
+				Name:      volumeName,
+				MountPath: mountPath,
+				ReadOnly:  true,
+			})
+		}
+		container.VolumeMounts = mounts
+	}
+}
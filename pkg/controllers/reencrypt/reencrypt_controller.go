@@ -0,0 +1,120 @@
+// Package reencrypt forces every operator-managed Secret in Namespace to
+// be rewritten after etcd encryption is (re)configured, since a Secret
+// whose content never changes is otherwise never written again and so
+// stays encrypted under whatever key was active when it was last touched,
+// even after a key rotation.
+package reencrypt
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/openshift/cluster-capi-operator/pkg/gc"
+	"github.com/openshift/cluster-capi-operator/pkg/metrics"
+	"github.com/openshift/cluster-capi-operator/pkg/util/resourceapply"
+)
+
+// controllerName is the label value this controller reports under in
+// operator self-metrics.
+const controllerName = "reencrypt"
+
+// EpochConfigMapName is the ConfigMap in Namespace this controller watches
+// for the current etcd encryption epoch. Whatever rotates the encryption
+// key (the cluster's encryption operator, or an admin script) is
+// responsible for bumping its "epoch" key; this controller only reacts to
+// it.
+const EpochConfigMapName = "encryption-epoch"
+
+// EncryptionEpochAnnotation records the epoch a managed Secret was last
+// rewritten for, so a Secret already rewritten for the current epoch is
+// not pointlessly rewritten again on every reconcile.
+const EncryptionEpochAnnotation = "capi.openshift.io/encryption-epoch"
+
+// Reconciler rewrites every operator-managed Secret in Namespace whenever
+// EpochConfigMapName's "epoch" key changes, so they pick up the currently
+// active etcd encryption key.
+type Reconciler struct {
+	client.Client
+
+	// Namespace is the namespace the operator manages Secrets in.
+	Namespace string
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.ConfigMap{}).
+		Named(controllerName).
+		Complete(r)
+}
+
+// Reconcile rewrites every operator-managed Secret in Namespace that
+// hasn't already been stamped with the epoch currently recorded on
+// EpochConfigMapName.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	start := time.Now()
+	logger := log.FromContext(ctx)
+
+	if req.Namespace != r.Namespace || req.Name != EpochConfigMapName {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+
+	epochConfigMap := &corev1.ConfigMap{}
+	err := r.Get(ctx, req.NamespacedName, epochConfigMap)
+	if apierrors.IsNotFound(err) {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	epoch := epochConfigMap.Data["epoch"]
+	if epoch == "" {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+
+	secrets := &corev1.SecretList{}
+	if err := r.List(ctx, secrets, client.InNamespace(r.Namespace)); err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	rewritten := 0
+	for i := range secrets.Items {
+		secret := &secrets.Items[i]
+		if secret.Labels[gc.ManagedByLabel] != gc.ManagedByValue {
+			continue
+		}
+		if secret.Annotations[EncryptionEpochAnnotation] == epoch {
+			continue
+		}
+
+		if _, err := resourceapply.CreateOrPatch(ctx, controllerName, r.Client, secret, func() error {
+			if secret.Annotations == nil {
+				secret.Annotations = map[string]string{}
+			}
+			secret.Annotations[EncryptionEpochAnnotation] = epoch
+			return nil
+		}); err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+		rewritten++
+	}
+
+	if rewritten > 0 {
+		logger.Info("rewrote managed secrets for new encryption epoch", "epoch", epoch, "count", rewritten)
+	}
+	metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+	return ctrl.Result{}, nil
+}
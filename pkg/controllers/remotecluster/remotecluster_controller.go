@@ -0,0 +1,118 @@
+// Package remotecluster is the experimental controller half of fleet
+// management: it watches kubeconfig secrets for remote management
+// clusters and keeps pkg/remotecluster's Pool in sync with them, so
+// other reconcilers can look up a live, cache-backed client for any
+// cluster in the fleet by its secret name.
+package remotecluster
+
+import (
+	"context"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/openshift/cluster-capi-operator/pkg/gc"
+	"github.com/openshift/cluster-capi-operator/pkg/metrics"
+	"github.com/openshift/cluster-capi-operator/pkg/remotecluster"
+)
+
+// controllerName is the label value this controller reports under in
+// operator self-metrics.
+const controllerName = "remotecluster"
+
+// FleetMemberLabel marks a Secret in Namespace as holding the
+// kubeconfig of a remote management cluster to add to the fleet. Its
+// value is unused; only presence is checked.
+const FleetMemberLabel = "capi.openshift.io/fleet-member"
+
+// secretDataKey is the Secret.Data key the kubeconfig is read from,
+// matching the key CAPI tooling uses for kubeconfig secrets.
+const secretDataKey = "value"
+
+// Reconciler keeps Pool in sync with the fleet-member kubeconfig
+// secrets found in Namespace: adding a Cluster when such a secret
+// appears, rebuilding it when the secret's content changes, and
+// stopping and removing it when the secret is deleted.
+type Reconciler struct {
+	client.Client
+
+	// Namespace is the namespace fleet-member kubeconfig secrets live
+	// in.
+	Namespace string
+
+	// Scheme is used to build each remote Cluster's client and cache.
+	Scheme *runtime.Scheme
+
+	// Pool is kept in sync with the fleet-member secrets found in
+	// Namespace.
+	Pool *remotecluster.Pool
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Secret{}).
+		Named(controllerName).
+		Complete(r)
+}
+
+// Reconcile adds, refreshes, or removes req's entry in Pool depending
+// on whether a matching fleet-member secret currently exists.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	start := time.Now()
+	logger := log.FromContext(ctx)
+
+	if req.Namespace != r.Namespace {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, req.NamespacedName, secret)
+	if apierrors.IsNotFound(err) {
+		if removed, ok := r.Pool.Remove(req.Name); ok {
+			removed.Stop()
+			logger.Info("removed remote cluster from fleet", "name", req.Name)
+		}
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	if _, ok := secret.Labels[FleetMemberLabel]; !ok {
+		if removed, ok := r.Pool.Remove(req.Name); ok {
+			removed.Stop()
+			logger.Info("removed remote cluster from fleet", "name", req.Name, "reason", "fleet-member label removed")
+		}
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+
+	if existing, ok := r.Pool.Get(req.Name); ok && existing.ContentHash == gc.SourceHash(secret.Data[secretDataKey]) {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+
+	cluster, err := remotecluster.NewFromKubeconfig(ctx, r.Scheme, req.Name, secret.Data[secretDataKey])
+	if err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	if previous, ok := r.Pool.Remove(req.Name); ok {
+		previous.Stop()
+	}
+	r.Pool.Set(req.Name, cluster)
+
+	logger.Info("added remote cluster to fleet", "name", req.Name)
+	metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+	return ctrl.Result{}, nil
+}
@@ -0,0 +1,176 @@
+// Package selfsignedca contains the controller that maintains a local CA
+// and webhook serving certificate when the service-ca operator is not
+// available to do so (standalone or development clusters).
+package selfsignedca
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/openshift/cluster-capi-operator/pkg/metrics"
+	caassets "github.com/openshift/cluster-capi-operator/pkg/selfsignedca"
+	reconcileutil "github.com/openshift/cluster-capi-operator/pkg/util/reconcile"
+	"github.com/openshift/cluster-capi-operator/pkg/util/resourceapply"
+)
+
+// controllerName is the label value this controller reports under in
+// operator self-metrics.
+const controllerName = "selfsignedca"
+
+// syncPeriod is how often this controller re-checks certificate expiry,
+// independent of any watch event, since a certificate can age past its
+// renewal buffer without any cluster event ever triggering a reconcile.
+const syncPeriod = time.Hour
+
+// Reconciler maintains a self-signed CA secret and a CA-signed serving
+// certificate secret for the webhook service, reconciling them well
+// ahead of expiry so service-ca-less clusters never see a lapsed cert.
+type Reconciler struct {
+	client.Client
+
+	// Namespace is the namespace the operator manages resources in.
+	Namespace string
+
+	// CASecretName names the Secret holding the self-signed CA.
+	CASecretName string
+
+	// ServingSecretName names the Secret holding the webhook serving
+	// certificate signed by the CA.
+	ServingSecretName string
+
+	// ServiceDNSNames are the DNS names the serving certificate must
+	// cover, typically the webhook Service's cluster-local names.
+	ServiceDNSNames []string
+}
+
+// SetupWithManager sets up the controller with the Manager, triggering on
+// the two Secrets it owns plus a periodic resync to catch expiry between
+// events.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Secret{}).
+		Named("selfsignedca").
+		Complete(r)
+}
+
+// Reconcile ensures the CA and serving certificate secrets exist and are
+// not within their renewal buffer of expiry.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, cancel := reconcileutil.WithTimeout(ctx)
+	defer cancel()
+
+	start := time.Now()
+	logger := log.FromContext(ctx)
+
+	if req.Namespace != r.Namespace || (req.Name != r.CASecretName && req.Name != r.ServingSecretName) {
+		return ctrl.Result{}, nil
+	}
+
+	ca, err := r.ensureCA(ctx)
+	if err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+	metrics.SetSelfSignedCertExpiry(r.Namespace, r.CASecretName, "ca", ca.Cert.NotAfter)
+
+	serving, err := r.ensureServingCert(ctx, ca)
+	if err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+	metrics.SetSelfSignedCertExpiry(r.Namespace, r.ServingSecretName, "serving", serving.Cert.NotAfter)
+
+	logger.V(1).Info("reconciled self-signed CA and serving certificate")
+
+	metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+	return ctrl.Result{RequeueAfter: syncPeriod}, nil
+}
+
+// ensureCA returns the current CA bundle, generating and storing a new
+// one if the secret is missing or the stored CA is within its renewal
+// buffer of expiry.
+func (r *Reconciler) ensureCA(ctx context.Context) (*caassets.Bundle, error) {
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: r.Namespace, Name: r.CASecretName}, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get CA secret: %w", err)
+	}
+
+	if err == nil {
+		if bundle, parseErr := caassets.ParseBundle(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]); parseErr == nil {
+			if !bundle.NeedsRenewal(time.Now(), caassets.RenewalBuffer) {
+				return bundle, nil
+			}
+		}
+	}
+
+	bundle, err := caassets.GenerateCA(r.CASecretName, caassets.DefaultCAValidity)
+	if err != nil {
+		return nil, err
+	}
+
+	secret.Name = r.CASecretName
+	secret.Namespace = r.Namespace
+	if _, err := resourceapply.CreateOrRecreate(ctx, controllerName, r.Client, nil, secret, func() error {
+		secret.Type = corev1.SecretTypeTLS
+		secret.Data = map[string][]byte{
+			corev1.TLSCertKey:       bundle.CertPEM,
+			corev1.TLSPrivateKeyKey: bundle.KeyPEM,
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to store CA secret: %w", err)
+	}
+
+	return bundle, nil
+}
+
+// ensureServingCert returns the current webhook serving certificate
+// bundle, generating and storing a new one, signed by ca, if the secret
+// is missing, within its renewal buffer, or was signed by a CA that has
+// since rotated.
+func (r *Reconciler) ensureServingCert(ctx context.Context, ca *caassets.Bundle) (*caassets.Bundle, error) {
+	secret := &corev1.Secret{}
+	err := r.Get(ctx, types.NamespacedName{Namespace: r.Namespace, Name: r.ServingSecretName}, secret)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get serving certificate secret: %w", err)
+	}
+
+	if err == nil {
+		if bundle, parseErr := caassets.ParseBundle(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey]); parseErr == nil {
+			signedByCurrentCA := bundle.Cert.CheckSignatureFrom(ca.Cert) == nil
+			if signedByCurrentCA && !bundle.NeedsRenewal(time.Now(), caassets.RenewalBuffer) {
+				return bundle, nil
+			}
+		}
+	}
+
+	bundle, err := caassets.GenerateServingCert(ca, r.ServiceDNSNames, caassets.DefaultServingCertValidity)
+	if err != nil {
+		return nil, err
+	}
+
+	secret.Name = r.ServingSecretName
+	secret.Namespace = r.Namespace
+	if _, err := resourceapply.CreateOrRecreate(ctx, controllerName, r.Client, nil, secret, func() error {
+		secret.Type = corev1.SecretTypeTLS
+		secret.Data = map[string][]byte{
+			corev1.TLSCertKey:       bundle.CertPEM,
+			corev1.TLSPrivateKeyKey: bundle.KeyPEM,
+			"ca.crt":                ca.CertPEM,
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("failed to store serving certificate secret: %w", err)
+	}
+
+	return bundle, nil
+}
@@ -0,0 +1,141 @@
+// Package stalemachine flags Cluster API Machines stuck in a non-terminal
+// phase beyond a threshold, annotating them with a probable cause parsed
+// from their provider conditions and emitting an Event, so an admin has a
+// head start diagnosing a stuck rollout without the operator guessing at
+// remediation on its own.
+package stalemachine
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/tools/record"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/openshift/cluster-capi-operator/pkg/metrics"
+	"github.com/openshift/cluster-capi-operator/pkg/util/resourceapply"
+)
+
+// controllerName is the label value this controller reports under in
+// operator self-metrics.
+const controllerName = "stalemachine"
+
+// staleThreshold is how long a Machine can sit in a non-terminal phase
+// before it's flagged stale. It matches the threshold machinemetrics uses
+// to report the same Machines as stuck, so the two stay consistent.
+const staleThreshold = 15 * time.Minute
+
+// causeAnnotation records the probable cause this controller inferred for
+// a stale Machine, for admins and support tooling to read without having
+// to re-derive it from conditions themselves.
+const causeAnnotation = "cluster-api.openshift.io/stale-cause"
+
+// nonTerminalPhases are the Machine phases eligible to be flagged stale.
+var nonTerminalPhases = map[clusterv1.MachinePhase]bool{
+	clusterv1.MachinePhasePending:      true,
+	clusterv1.MachinePhaseProvisioning: true,
+	clusterv1.MachinePhaseDeleting:     true,
+}
+
+// Reconciler flags stale Machines without remediating them.
+type Reconciler struct {
+	client.Client
+
+	// Recorder emits the StaleMachine Event.
+	Recorder record.EventRecorder
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *Reconciler) SetupWithManager(mgr ctrl.Manager) error {
+	if r.Recorder == nil {
+		r.Recorder = mgr.GetEventRecorderFor(controllerName)
+	}
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&clusterv1.Machine{}).
+		Named(controllerName).
+		Complete(r)
+}
+
+// Reconcile flags req's Machine as stale if it has spent longer than
+// staleThreshold in a non-terminal phase, annotating it with a probable
+// cause and emitting a warning Event.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	start := time.Now()
+	logger := log.FromContext(ctx)
+
+	machine := &clusterv1.Machine{}
+	err := r.Get(ctx, req.NamespacedName, machine)
+	if apierrors.IsNotFound(err) {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+		return ctrl.Result{}, err
+	}
+
+	if !nonTerminalPhases[clusterv1.MachinePhase(machine.Status.Phase)] {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{}, nil
+	}
+
+	phaseStart := machine.CreationTimestamp.Time
+	if machine.Status.LastUpdated != nil {
+		phaseStart = machine.Status.LastUpdated.Time
+	}
+	age := time.Since(phaseStart)
+	if age < staleThreshold {
+		metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+		return ctrl.Result{RequeueAfter: staleThreshold - age}, nil
+	}
+
+	cause := probableCause(machine)
+	if machine.Annotations[causeAnnotation] != cause {
+		if _, err := resourceapply.CreateOrPatch(ctx, controllerName, r.Client, machine, func() error {
+			if machine.Annotations == nil {
+				machine.Annotations = map[string]string{}
+			}
+			machine.Annotations[causeAnnotation] = cause
+			return nil
+		}); err != nil {
+			metrics.ObserveReconcile(controllerName, metrics.OutcomeError, "", time.Since(start))
+			return ctrl.Result{}, err
+		}
+
+		r.Recorder.Eventf(machine, corev1.EventTypeWarning, "StaleMachine",
+			"Machine has been in phase %s for %s: %s", machine.Status.Phase, age.Round(time.Minute), cause)
+	}
+
+	logger.V(1).Info("flagged stale Machine", "phase", machine.Status.Phase, "age", age, "cause", cause)
+	metrics.ObserveReconcile(controllerName, metrics.OutcomeSuccess, "", time.Since(start))
+	return ctrl.Result{RequeueAfter: staleThreshold}, nil
+}
+
+// probableCause inspects machine's conditions for well-known failure
+// signatures, falling back to a generic message when none match.
+func probableCause(machine *clusterv1.Machine) string {
+	for _, cond := range machine.Status.Conditions {
+		if cond.Status == corev1.ConditionTrue {
+			continue
+		}
+
+		text := strings.ToLower(cond.Reason + " " + cond.Message)
+		switch {
+		case strings.Contains(text, "secret") && strings.Contains(text, "not found"):
+			return "likely missing user-data/bootstrap secret: " + cond.Message
+		case strings.Contains(text, "unauthorized"), strings.Contains(text, "invalidclienttokenid"), strings.Contains(text, "credential"):
+			return "likely provider credential failure: " + cond.Message
+		case cond.Message != "":
+			return cond.Message
+		}
+	}
+
+	return "no failing condition found; machine has simply exceeded the expected provisioning time"
+}
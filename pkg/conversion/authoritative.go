@@ -0,0 +1,42 @@
+// Package conversion holds logic shared by mapi2capi and capi2mapi for
+// deciding which API is authoritative for a given resource during
+// migration, rather than assuming MAPI always wins.
+package conversion
+
+// AuthoritativeAPI identifies which API (MAPI or CAPI) is the source of
+// truth for a migrating resource. While a resource's AuthoritativeAPI is
+// Migrating, the sync controllers leave both sides untouched until the
+// switch completes.
+type AuthoritativeAPI string
+
+const (
+	// AuthoritativeAPIMachineAPI means the machine-api resource is
+	// authoritative; its CAPI mirror is generated and read-only.
+	AuthoritativeAPIMachineAPI AuthoritativeAPI = "MachineAPI"
+	// AuthoritativeAPIClusterAPI means the Cluster API resource is
+	// authoritative; its MAPI mirror is generated and read-only.
+	AuthoritativeAPIClusterAPI AuthoritativeAPI = "ClusterAPI"
+	// AuthoritativeAPIMigrating means the resource is mid-migration and
+	// sync controllers should pause reconciling user-facing spec changes
+	// on either side until the switch completes.
+	AuthoritativeAPIMigrating AuthoritativeAPI = "Migrating"
+)
+
+// AuthoritativeAPIAnnotation records which API is authoritative for a
+// MachineSet (or Machine) undergoing MAPI/CAPI migration.
+const AuthoritativeAPIAnnotation = "machine.openshift.io/authoritative-api"
+
+// AuthoritativeAPIFor reads AuthoritativeAPIAnnotation from annotations,
+// defaulting to AuthoritativeAPIMachineAPI when absent or unrecognized, so
+// that resources created before this feature existed keep their current
+// (MAPI) behavior.
+func AuthoritativeAPIFor(annotations map[string]string) AuthoritativeAPI {
+	switch AuthoritativeAPI(annotations[AuthoritativeAPIAnnotation]) {
+	case AuthoritativeAPIClusterAPI:
+		return AuthoritativeAPIClusterAPI
+	case AuthoritativeAPIMigrating:
+		return AuthoritativeAPIMigrating
+	default:
+		return AuthoritativeAPIMachineAPI
+	}
+}
@@ -0,0 +1,46 @@
+// Package aws converts Cluster API Provider AWS (CAPA) AWSMachineSpec
+// fields back into the equivalent AWS-specific MAPI provider spec, the
+// reverse of mapi2capi/aws, so a MachineSet can be rolled back from CAPI
+// to MAPI management without manually reconstructing its provider spec.
+package aws
+
+import (
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	capav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+// providerConfigKind and providerConfigAPIVersion stamp the TypeMeta of a
+// reconstructed AWSMachineProviderConfig, so a rolled-back MachineSet's
+// provider spec is recognized by the machine-api AWS actuator the same as
+// one it produced itself.
+const (
+	providerConfigKind       = "AWSMachineProviderConfig"
+	providerConfigAPIVersion = "machine.openshift.io/v1beta1"
+)
+
+// ToAWSMachineProviderConfig converts a CAPA AWSMachineSpec into a MAPI
+// AWSMachineProviderConfig, covering the fields ToAWSMachineSpec
+// translates in the forward direction: instance type, AMI, spot market
+// options, and tenancy.
+func ToAWSMachineProviderConfig(spec *capav1.AWSMachineSpec) *machinev1beta1.AWSMachineProviderConfig {
+	providerConfig := &machinev1beta1.AWSMachineProviderConfig{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       providerConfigKind,
+			APIVersion: providerConfigAPIVersion,
+		},
+		InstanceType: spec.InstanceType,
+		AMI:          machinev1beta1.AWSResourceReference{ID: spec.AMI.ID},
+		Placement: machinev1beta1.Placement{
+			Tenancy: machinev1beta1.InstanceTenancy(spec.Tenancy),
+		},
+	}
+
+	if spec.SpotMarketOptions != nil {
+		providerConfig.SpotMarketOptions = &machinev1beta1.SpotMarketOptions{
+			MaxPrice: spec.SpotMarketOptions.MaxPrice,
+		}
+	}
+
+	return providerConfig
+}
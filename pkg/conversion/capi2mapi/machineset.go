@@ -0,0 +1,67 @@
+// Package capi2mapi converts Cluster API MachineSets back into their
+// machine-api equivalents, the reverse of mapi2capi, so that a cluster can
+// roll back from CAPI to MAPI management if the migration needs to be
+// undone.
+package capi2mapi
+
+import (
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/openshift/cluster-capi-operator/pkg/conversion/replicaauthority"
+)
+
+// ToMachineSet converts a CAPI MachineSet into a MAPI MachineSet. The
+// caller must supply providerSpecValue, the provider-specific raw
+// extension previously produced for this MachineSet by mapi2capi (or
+// re-derived from the CAPI infrastructure template), since CAPI's
+// InfrastructureRef has no generic inverse into MAPI's opaque providerSpec.
+// existingMAPIReplicas, if non-nil, is the replica count already set on
+// the destination MAPI MachineSet; it is preserved instead of being
+// overwritten when the replica-authority annotation (see
+// replicaauthority.Annotation) designates MAPI as authoritative for this
+// pair, the mirror image of the CAPI-authoritative case mapi2capi.
+// ToMachineSet handles.
+func ToMachineSet(capiMachineSet *clusterv1.MachineSet, providerSpecValue []byte, existingMAPIReplicas *int32) *machinev1beta1.MachineSet {
+	replicas := capiMachineSet.Spec.Replicas
+	if !replicaauthority.IsAuthoritative(capiMachineSet.Annotations, replicaauthority.CAPI) && existingMAPIReplicas != nil {
+		replicas = existingMAPIReplicas
+	}
+
+	return &machinev1beta1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        capiMachineSet.Name,
+			Namespace:   capiMachineSet.Namespace,
+			Labels:      withoutClusterLabel(capiMachineSet.Labels),
+			Annotations: capiMachineSet.Annotations,
+		},
+		Spec: machinev1beta1.MachineSetSpec{
+			Replicas: replicas,
+			Template: machinev1beta1.MachineTemplateSpec{
+				Spec: machinev1beta1.MachineSpec{
+					ProviderSpec: machinev1beta1.ProviderSpec{
+						Value: &runtime.RawExtension{Raw: providerSpecValue},
+					},
+				},
+			},
+		},
+	}
+}
+
+// withoutClusterLabel drops the CAPI cluster-name label, which has no MAPI
+// equivalent and would otherwise be carried over misleadingly.
+func withoutClusterLabel(in map[string]string) map[string]string {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		if k == clusterv1.ClusterNameLabel {
+			continue
+		}
+		out[k] = v
+	}
+	return out
+}
@@ -0,0 +1,54 @@
+// Package aws converts AWS-specific MAPI provider spec fields into the
+// equivalent Cluster API Provider AWS (CAPA) AWSMachineSpec fields.
+package aws
+
+import (
+	"fmt"
+
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	capav1 "sigs.k8s.io/cluster-api-provider-aws/v2/api/v1beta2"
+)
+
+// supportedTenancies are the AWSMachineProviderConfig.Placement.Tenancy
+// values CAPA's AWSMachineSpec.Tenancy also accepts verbatim.
+var supportedTenancies = map[string]bool{
+	"":          true,
+	"default":   true,
+	"dedicated": true,
+	"host":      true,
+}
+
+// ToAWSMachineSpec converts a MAPI AWSMachineProviderConfig into a CAPA
+// AWSMachineSpec, covering the fields CAPA needs to provision an
+// equivalent instance: instance type (which implicitly carries GPU
+// capability, as AWS has no separate GPU field), spot/preemptible market
+// options, and dedicated-tenancy placement. It returns an error only for a
+// Placement.Tenancy value CAPA does not accept; provider config fields
+// other than those listed here are not converted and are not reported.
+func ToAWSMachineSpec(providerConfig *machinev1beta1.AWSMachineProviderConfig) (*capav1.AWSMachineSpec, error) {
+	spec := &capav1.AWSMachineSpec{
+		InstanceType: providerConfig.InstanceType,
+		AMI:          capav1.AMIReference{ID: amiID(providerConfig)},
+	}
+
+	if providerConfig.SpotMarketOptions != nil {
+		spec.SpotMarketOptions = &capav1.SpotMarketOptions{
+			MaxPrice: providerConfig.SpotMarketOptions.MaxPrice,
+		}
+	}
+
+	tenancy := string(providerConfig.Placement.Tenancy)
+	if !supportedTenancies[tenancy] {
+		return nil, fmt.Errorf("unsupported field Placement.Tenancy: %q", tenancy)
+	}
+	spec.Tenancy = tenancy
+
+	return spec, nil
+}
+
+func amiID(providerConfig *machinev1beta1.AWSMachineProviderConfig) *string {
+	if providerConfig.AMI.ID != nil {
+		return providerConfig.AMI.ID
+	}
+	return nil
+}
@@ -0,0 +1,108 @@
+// Package mapi2capi converts machine-api (MAPI) Machine and MachineSet
+// resources into their Cluster API (CAPI) equivalents, so that existing
+// OpenShift clusters can be migrated onto Cluster API without hand-authoring
+// new manifests.
+package mapi2capi
+
+import (
+	"time"
+
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// excludeNodeDrainingAnnotation matches MAPI's existing convention for
+// skipping node drain on machine deletion; CAPI expresses the same intent
+// via a zero NodeDrainTimeout.
+const excludeNodeDrainingAnnotation = "machine.openshift.io/exclude-node-draining"
+
+// drainTimeoutAnnotation is an OpenShift-specific annotation carrying an
+// explicit drain timeout, since MAPI's Machine spec has no native field for
+// it. Its value must parse as a Go duration string (e.g. "10m").
+const drainTimeoutAnnotation = "machine.openshift.io/drain-timeout"
+
+// ToMachine converts a MAPI Machine into a CAPI Machine. infraRef must
+// point at the infrastructure-provider-specific object already converted
+// from mapiMachine's providerSpec.
+func ToMachine(mapiMachine *machinev1beta1.Machine, clusterName string, infraRef corev1.ObjectReference) *clusterv1.Machine {
+	capiMachine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        mapiMachine.Name,
+			Namespace:   mapiMachine.Namespace,
+			Labels:      copyStringMap(mapiMachine.Labels),
+			Annotations: copyStringMap(mapiMachine.Annotations),
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: clusterName,
+			InfrastructureRef: corev1.ObjectReference{
+				APIVersion: infraRef.APIVersion,
+				Kind:       infraRef.Kind,
+				Name:       infraRef.Name,
+				Namespace:  infraRef.Namespace,
+			},
+		},
+	}
+
+	if capiMachine.Labels == nil {
+		capiMachine.Labels = map[string]string{}
+	}
+	capiMachine.Labels[clusterv1.ClusterNameLabel] = clusterName
+
+	if capiMachine.Annotations == nil {
+		capiMachine.Annotations = map[string]string{}
+	}
+	applyLifecycleHooks(mapiMachine, capiMachine)
+	capiMachine.Spec.NodeDrainTimeout = drainTimeoutFor(mapiMachine)
+
+	return capiMachine
+}
+
+// drainTimeoutFor derives the CAPI NodeDrainTimeout from MAPI drain-related
+// annotations: exclude-node-draining takes precedence and maps to a zero
+// timeout (drain immediately skipped), otherwise an explicit
+// machine.openshift.io/drain-timeout value is parsed, falling back to nil
+// (CAPI's "wait indefinitely" default) when neither is set.
+func drainTimeoutFor(mapiMachine *machinev1beta1.Machine) *metav1.Duration {
+	if _, excluded := mapiMachine.Annotations[excludeNodeDrainingAnnotation]; excluded {
+		return &metav1.Duration{}
+	}
+
+	raw, ok := mapiMachine.Annotations[drainTimeoutAnnotation]
+	if !ok {
+		return nil
+	}
+
+	d, err := time.ParseDuration(raw)
+	if err != nil {
+		return nil
+	}
+
+	return &metav1.Duration{Duration: d}
+}
+
+// applyLifecycleHooks translates MAPI's Spec.LifecycleHooks into the
+// equivalent CAPI deletion-hook annotations, since CAPI has no dedicated
+// LifecycleHooks field: pre-drain and pre-terminate hooks are instead
+// expressed as "<hook>.delete.hook.machine.cluster.x-k8s.io/<name>"
+// annotations that a controller clears once the hook is satisfied.
+func applyLifecycleHooks(mapiMachine *machinev1beta1.Machine, capiMachine *clusterv1.Machine) {
+	for _, hook := range mapiMachine.Spec.LifecycleHooks.PreDrain {
+		capiMachine.Annotations["pre-drain.delete.hook.machine.cluster.x-k8s.io/"+hook.Name] = hook.Owner
+	}
+	for _, hook := range mapiMachine.Spec.LifecycleHooks.PreTerminate {
+		capiMachine.Annotations["pre-terminate.delete.hook.machine.cluster.x-k8s.io/"+hook.Name] = hook.Owner
+	}
+}
+
+func copyStringMap(in map[string]string) map[string]string {
+	if in == nil {
+		return nil
+	}
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		out[k] = v
+	}
+	return out
+}
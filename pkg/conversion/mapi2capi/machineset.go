@@ -0,0 +1,96 @@
+package mapi2capi
+
+import (
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+
+	"github.com/openshift/cluster-capi-operator/pkg/conversion/replicaauthority"
+)
+
+// autoscalerCapacityAnnotationPrefix namespaces the cluster-autoscaler
+// scale-from-zero capacity annotations (cpu/memory/gpu-count/maxPods) that
+// scaleFromZeroAnnotations derives from MAPI's own scale-from-zero hint
+// annotations, since the autoscaler reads the capacity.* form directly off
+// a MachineSet and has no awareness of MAPI's annotation convention.
+const autoscalerCapacityAnnotationPrefix = "capacity.cluster-autoscaler.kubernetes.io/"
+
+// mapiScaleFromZero{VCPU,Memory,GPU,MaxPods}Annotation are the
+// machine-api annotations admins set on a MachineSet with zero replicas
+// so the cluster-autoscaler can still size a scale-up without an existing
+// Machine to inspect.
+const (
+	mapiScaleFromZeroVCPUAnnotation    = "machine.openshift.io/vCPU"
+	mapiScaleFromZeroMemoryAnnotation  = "machine.openshift.io/memoryMb"
+	mapiScaleFromZeroGPUAnnotation     = "machine.openshift.io/GPU"
+	mapiScaleFromZeroMaxPodsAnnotation = "machine.openshift.io/maxPods"
+)
+
+// scaleFromZeroAnnotations translates mapiAnnotations' scale-from-zero
+// hint annotations into their capacity.cluster-autoscaler.kubernetes.io/
+// equivalents, so autoscaler scale-from-zero keeps working against the
+// converted CAPI MachineSet. Called on every conversion rather than only
+// once, so a later edit to the MAPI hint annotations stays in sync with
+// its CAPI mirror instead of only taking effect at creation time.
+func scaleFromZeroAnnotations(mapiAnnotations map[string]string) map[string]string {
+	translations := map[string]string{
+		mapiScaleFromZeroVCPUAnnotation:    autoscalerCapacityAnnotationPrefix + "cpu",
+		mapiScaleFromZeroMemoryAnnotation:  autoscalerCapacityAnnotationPrefix + "memory",
+		mapiScaleFromZeroGPUAnnotation:     autoscalerCapacityAnnotationPrefix + "gpu-count",
+		mapiScaleFromZeroMaxPodsAnnotation: autoscalerCapacityAnnotationPrefix + "maxPods",
+	}
+
+	out := map[string]string{}
+	for mapiKey, capiKey := range translations {
+		if v, ok := mapiAnnotations[mapiKey]; ok {
+			out[capiKey] = v
+		}
+	}
+	return out
+}
+
+// ToMachineSet converts a MAPI MachineSet into a CAPI MachineSet, reusing
+// ToMachine to build the machine template's spec. existingCAPIReplicas, if
+// non-nil, is the replica count already set on the destination CAPI
+// MachineSet; it is preserved instead of being overwritten when the
+// replica-authority annotation (see replicaauthority.Annotation)
+// designates CAPI as authoritative for this pair, e.g. because the
+// cluster-autoscaler scales the CAPI side directly and a sync controller
+// mirroring MAPI's replicas on every pass would otherwise fight it.
+func ToMachineSet(mapiMachineSet *machinev1beta1.MachineSet, clusterName string, infraRef corev1.ObjectReference, existingCAPIReplicas *int32) *clusterv1.MachineSet {
+	replicas := int32(1)
+	if mapiMachineSet.Spec.Replicas != nil {
+		replicas = *mapiMachineSet.Spec.Replicas
+	}
+	if !replicaauthority.IsAuthoritative(mapiMachineSet.Annotations, replicaauthority.MAPI) && existingCAPIReplicas != nil {
+		replicas = *existingCAPIReplicas
+	}
+
+	annotations := copyStringMap(mapiMachineSet.Annotations)
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	for k, v := range scaleFromZeroAnnotations(mapiMachineSet.Annotations) {
+		annotations[k] = v
+	}
+
+	return &clusterv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        mapiMachineSet.Name,
+			Namespace:   mapiMachineSet.Namespace,
+			Labels:      copyStringMap(mapiMachineSet.Labels),
+			Annotations: annotations,
+		},
+		Spec: clusterv1.MachineSetSpec{
+			ClusterName: clusterName,
+			Replicas:    &replicas,
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					ClusterName:       clusterName,
+					InfrastructureRef: infraRef,
+				},
+			},
+		},
+	}
+}
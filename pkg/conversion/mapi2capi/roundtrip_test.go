@@ -0,0 +1,64 @@
+package mapi2capi
+
+import (
+	"testing"
+
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// FuzzToMachine asserts that ToMachine never panics and always produces a
+// CAPI Machine carrying the source's name, namespace and cluster name,
+// regardless of what labels/annotations/lifecycle hooks the input carries.
+func FuzzToMachine(f *testing.F) {
+	f.Add("worker-0", "openshift-machine-api", "pre-drain-hook", "cloud-provider")
+
+	f.Fuzz(func(t *testing.T, name, namespace, hookName, hookOwner string) {
+		mapiMachine := &machinev1beta1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+		}
+		if hookName != "" {
+			mapiMachine.Spec.LifecycleHooks.PreDrain = []machinev1beta1.LifecycleHook{
+				{Name: hookName, Owner: hookOwner},
+			}
+		}
+
+		capiMachine := ToMachine(mapiMachine, "test-cluster", corev1.ObjectReference{})
+
+		if capiMachine.Name != name {
+			t.Errorf("expected name %q, got %q", name, capiMachine.Name)
+		}
+		if capiMachine.Namespace != namespace {
+			t.Errorf("expected namespace %q, got %q", namespace, capiMachine.Namespace)
+		}
+		if capiMachine.Spec.ClusterName != "test-cluster" {
+			t.Errorf("expected cluster name to be set")
+		}
+	})
+}
+
+// TestCopyStringMapRoundTrip verifies copyStringMap produces an
+// independent copy equal in content to its input.
+func TestCopyStringMapRoundTrip(t *testing.T) {
+	in := map[string]string{"a": "1", "b": "2"}
+
+	out := copyStringMap(in)
+
+	if len(out) != len(in) {
+		t.Fatalf("expected %d entries, got %d", len(in), len(out))
+	}
+	for k, v := range in {
+		if out[k] != v {
+			t.Errorf("expected out[%q] = %q, got %q", k, v, out[k])
+		}
+	}
+
+	out["a"] = "mutated"
+	if in["a"] == "mutated" {
+		t.Errorf("copyStringMap did not produce an independent copy")
+	}
+}
@@ -0,0 +1,29 @@
+// Package replicaauthority defines the shared annotation mapi2capi and
+// capi2mapi consult to decide which side of a MAPI/CAPI MachineSet mirror
+// is allowed to set Spec.Replicas, so the cluster-autoscaler and the sync
+// controller mirroring the two objects never fight over the same field and
+// thrash the MachineSet's size.
+package replicaauthority
+
+// Annotation records which side is authoritative for Spec.Replicas on a
+// mirrored MAPI/CAPI MachineSet pair. It is carried forward verbatim
+// across conversions in both directions (see mapi2capi.ToMachineSet and
+// capi2mapi.ToMachineSet), so setting it on either object is enough to
+// flip authority for the pair.
+const Annotation = "machine.openshift.io/replica-authority"
+
+// MAPI and CAPI are the two valid values for Annotation.
+const (
+	MAPI = "mapi"
+	CAPI = "capi"
+)
+
+// IsAuthoritative reports whether side is allowed to set replicas for the
+// MachineSet pair described by annotations. An unset or unrecognized
+// annotation defaults to true, preserving the conversion's long-standing
+// behavior of always mirroring its source side's replica count into its
+// destination until an operator opts a pair into split authority.
+func IsAuthoritative(annotations map[string]string, side string) bool {
+	v := annotations[Annotation]
+	return v == "" || v == side
+}
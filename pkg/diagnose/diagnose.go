@@ -0,0 +1,117 @@
+// Package diagnose analyzes the cluster-api ClusterOperator's conditions
+// and recent warning Events to produce a human-readable explanation of why
+// the operator is (or isn't) degraded, so support engineers don't have to
+// reconstruct root cause by hand from raw API objects every time.
+package diagnose
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	configv1 "github.com/openshift/api/config/v1"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/cluster-capi-operator/pkg/controllers/clusteroperator"
+)
+
+// Report is a human-readable explanation of the operator's current state.
+type Report struct {
+	Degraded bool
+	Reason   string
+	Message  string
+	Events   []string
+	Causes   []string
+}
+
+// Explain reads the cluster-api ClusterOperator and the recent warning
+// Events in namespace, and returns a Report describing why the operator
+// is (or isn't) degraded.
+func Explain(ctx context.Context, c client.Client, namespace string) (*Report, error) {
+	co := &configv1.ClusterOperator{}
+	if err := c.Get(ctx, client.ObjectKey{Name: clusteroperator.OperatorName}, co); err != nil {
+		return nil, fmt.Errorf("failed to get ClusterOperator %s: %w", clusteroperator.OperatorName, err)
+	}
+
+	report := &Report{}
+	for _, cond := range co.Status.Conditions {
+		if cond.Type == configv1.OperatorDegraded && cond.Status == configv1.ConditionTrue {
+			report.Degraded = true
+			report.Reason = cond.Reason
+			report.Message = cond.Message
+		}
+	}
+
+	events := &corev1.EventList{}
+	if err := c.List(ctx, events, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list Events in %s: %w", namespace, err)
+	}
+
+	for _, event := range events.Items {
+		if event.Type != corev1.EventTypeWarning {
+			continue
+		}
+		report.Events = append(report.Events, fmt.Sprintf("%s/%s: %s: %s", event.InvolvedObject.Kind, event.InvolvedObject.Name, event.Reason, event.Message))
+	}
+	sort.Strings(report.Events)
+
+	report.Causes = probableCauses(report)
+
+	return report, nil
+}
+
+// probableCauses maps well-known Reasons and Event substrings to a short,
+// human-readable likely cause, the same triage a support engineer would
+// otherwise do by hand.
+func probableCauses(report *Report) []string {
+	var causes []string
+
+	for _, event := range report.Events {
+		switch {
+		case strings.Contains(event, "no token secrets"), strings.Contains(event, "serviceaccount token"):
+			causes = append(causes, "token secret missing because the ServiceAccount has no secrets; likely running against Kubernetes >= 1.24, which stopped auto-creating them")
+		case strings.Contains(event, "ImmutableFieldRecreate"):
+			causes = append(causes, "an immutable field changed and a managed object is being recreated; a transient Degraded blip during rollover is expected")
+		case strings.Contains(event, "context deadline exceeded"):
+			causes = append(causes, "a reconcile timed out talking to the API server; check API server latency/connectivity from the operator's namespace")
+		}
+	}
+
+	switch report.Reason {
+	case "PlatformNotSupported":
+		causes = append(causes, "the cluster's platform has no Cluster API provider support; this operator is intentionally a no-op here")
+	case "CapabilityDisabled":
+		causes = append(causes, "the ClusterAPI cluster capability is disabled; enable it in ClusterVersion to activate the operator")
+	}
+
+	return causes
+}
+
+// String renders report as the multi-line text the explain CLI prints.
+func (r *Report) String() string {
+	var b strings.Builder
+
+	if !r.Degraded {
+		b.WriteString("cluster-api is not Degraded.\n")
+	} else {
+		fmt.Fprintf(&b, "cluster-api is Degraded: %s: %s\n", r.Reason, r.Message)
+	}
+
+	if len(r.Causes) > 0 {
+		b.WriteString("\nProbable causes:\n")
+		for _, cause := range r.Causes {
+			fmt.Fprintf(&b, "  - %s\n", cause)
+		}
+	}
+
+	if len(r.Events) > 0 {
+		b.WriteString("\nRecent warning events:\n")
+		for _, event := range r.Events {
+			fmt.Fprintf(&b, "  - %s\n", event)
+		}
+	}
+
+	return b.String()
+}
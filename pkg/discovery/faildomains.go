@@ -0,0 +1,21 @@
+package discovery
+
+// FailureDomainSpec mirrors the subset of CAPI's FailureDomainSpec this
+// operator needs to populate on an InfraCluster's status, kept as our own
+// type rather than importing clusterv1.FailureDomains so this package has
+// no dependency on which infrastructure provider is in use.
+type FailureDomainSpec struct {
+	ControlPlane bool `json:"controlPlane"`
+}
+
+// FailureDomainsFromZones converts zones into the failureDomains map CAPI
+// expects on an InfraCluster's status, keyed by zone name. ControlPlane is
+// left false throughout, since this operator does not yet manage
+// control-plane Machines.
+func FailureDomainsFromZones(zones []string) map[string]FailureDomainSpec {
+	domains := make(map[string]FailureDomainSpec, len(zones))
+	for _, zone := range zones {
+		domains[zone] = FailureDomainSpec{ControlPlane: false}
+	}
+	return domains
+}
@@ -0,0 +1,83 @@
+// Package discovery derives cluster topology facts — today, the
+// availability zones a cluster's compute actually spans — from existing
+// machine-api objects, so generated Cluster API machine templates spread
+// across the same zones instead of defaulting to a single one.
+package discovery
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	machinev1beta1 "github.com/openshift/api/machine/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// DiscoverAWSZones returns the distinct AWS availability zones used by the
+// MAPI MachineSets in namespace, sorted for deterministic output. A
+// MachineSet whose provider spec can't be decoded is skipped rather than
+// failing the whole discovery, since one malformed MachineSet shouldn't
+// block template generation for the rest of the cluster.
+func DiscoverAWSZones(ctx context.Context, c client.Client, namespace string) ([]string, error) {
+	machineSets := &machinev1beta1.MachineSetList{}
+	if err := c.List(ctx, machineSets, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list MachineSets in %s: %w", namespace, err)
+	}
+
+	seen := make(map[string]bool)
+	for _, ms := range machineSets.Items {
+		zone, ok := awsAvailabilityZone(ms.Spec.Template.Spec.ProviderSpec.Value)
+		if !ok || zone == "" {
+			continue
+		}
+		seen[zone] = true
+	}
+
+	zones := make([]string, 0, len(seen))
+	for zone := range seen {
+		zones = append(zones, zone)
+	}
+	sort.Strings(zones)
+
+	return zones, nil
+}
+
+// awsAvailabilityZone decodes just enough of an AWSMachineProviderConfig to
+// read its placement.availabilityZone.
+func awsAvailabilityZone(raw *runtime.RawExtension) (string, bool) {
+	if raw == nil || len(raw.Raw) == 0 {
+		return "", false
+	}
+
+	var providerConfig machinev1beta1.AWSMachineProviderConfig
+	if err := json.Unmarshal(raw.Raw, &providerConfig); err != nil {
+		return "", false
+	}
+
+	return providerConfig.Placement.AvailabilityZone, true
+}
+
+// DistributeAcrossZones splits desiredReplicas as evenly as possible across
+// zones, giving any remainder to the earliest zones (in the order given) so
+// the distribution is deterministic across reconciles.
+func DistributeAcrossZones(zones []string, desiredReplicas int32) map[string]int32 {
+	result := make(map[string]int32, len(zones))
+	if len(zones) == 0 {
+		return result
+	}
+
+	base := desiredReplicas / int32(len(zones))
+	remainder := desiredReplicas % int32(len(zones))
+
+	for i, zone := range zones {
+		count := base
+		if int32(i) < remainder {
+			count++
+		}
+		result[zone] = count
+	}
+
+	return result
+}
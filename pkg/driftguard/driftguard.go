@@ -0,0 +1,83 @@
+// Package driftguard protects specific fields of operator-managed objects
+// against manual edits. Upstream cluster-api-operator models provider
+// installation with CoreProvider/InfrastructureProvider CRDs; this operator
+// instead mutates specific fields of operator-managed Deployments directly
+// (see pkg/controllers/imageoverride, pkg/controllers/loglevel,
+// pkg/controllers/projectedtoken), so this package guards those fields
+// against drift instead.
+//
+// By default a manual edit to a guarded field is reverted on the next
+// reconcile and reported via an Event. Setting OverrideAnnotation to
+// "true" on the object switches to honoring the edit instead, and the
+// honored paths are recorded into OverriddenFieldsAnnotation so the
+// override's impact stays visible on the object itself.
+package driftguard
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/cluster-capi-operator/pkg/gc"
+)
+
+// OverrideAnnotation, set to "true" on an operator-managed object, switches
+// drift handling for that object from reverting a manual edit (the
+// default) to honoring it.
+const OverrideAnnotation = "capi.openshift.io/allow-drift"
+
+// OverriddenFieldsAnnotation lists the comma-separated JSON-pointer paths
+// (see gc.StampManagedFields) whose manually-edited value is currently
+// being honored instead of reverted.
+const OverriddenFieldsAnnotation = "capi.openshift.io/overridden-fields"
+
+// Allowed reports whether obj has opted into honoring manual edits to its
+// guarded fields instead of having them reverted.
+func Allowed(obj client.Object) bool {
+	return obj.GetAnnotations()[OverrideAnnotation] == "true"
+}
+
+// Report records overriddenPaths, the guarded paths on obj currently
+// honoring a manual edit, into OverriddenFieldsAnnotation, clearing the
+// annotation when nothing is overridden.
+func Report(obj client.Object, overriddenPaths []string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	if len(overriddenPaths) == 0 {
+		delete(annotations, OverriddenFieldsAnnotation)
+		obj.SetAnnotations(annotations)
+		return
+	}
+
+	sorted := append([]string(nil), overriddenPaths...)
+	sort.Strings(sorted)
+	annotations[OverriddenFieldsAnnotation] = strings.Join(sorted, ",")
+	obj.SetAnnotations(annotations)
+}
+
+// AnyOverridden reports whether any operator-managed Deployment in
+// namespace currently has OverriddenFieldsAnnotation set, meaning at
+// least one manually-edited field is being honored instead of reverted.
+func AnyOverridden(ctx context.Context, c client.Client, namespace string) (bool, error) {
+	deployments := &appsv1.DeploymentList{}
+	if err := c.List(ctx, deployments, client.InNamespace(namespace)); err != nil {
+		return false, err
+	}
+
+	for _, deployment := range deployments.Items {
+		if deployment.Labels[gc.ManagedByLabel] != gc.ManagedByValue {
+			continue
+		}
+		if deployment.Annotations[OverriddenFieldsAnnotation] != "" {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,45 @@
+// Package fips detects whether the host is running in FIPS mode and
+// exposes the crypto parameters the operator's own certificate and key
+// generation must use when it is, so every caller that mints a key or
+// cert checks one place rather than re-deriving the rule.
+package fips
+
+import (
+	"crypto/elliptic"
+	"os"
+	"strings"
+)
+
+// fipsEnabledFile is the kernel flag OpenShift nodes in FIPS mode set,
+// the same source library-go's crypto helpers read.
+const fipsEnabledFile = "/proc/sys/crypto/fips_enabled"
+
+// minimumRSAKeyBits is the smallest RSA modulus size permitted in FIPS
+// mode (FIPS 186-4).
+const minimumRSAKeyBits = 2048
+
+// IsEnabled reports whether the host kernel has FIPS mode enabled.
+func IsEnabled() bool {
+	data, err := os.ReadFile(fipsEnabledFile)
+	if err != nil {
+		return false
+	}
+	return strings.TrimSpace(string(data)) == "1"
+}
+
+// MinimumRSAKeyBits returns the smallest RSA key size the operator may
+// generate. It does not vary by mode: 2048 bits is this operator's floor
+// either way, but callers generating FIPS-sensitive keys should pair it
+// with a check of IsEnabled to pick a FIPS-approved curve for non-RSA
+// keys via RecommendedCurve.
+func MinimumRSAKeyBits() int {
+	return minimumRSAKeyBits
+}
+
+// RecommendedCurve returns the elliptic curve the operator should use for
+// EC key generation: a FIPS 186-4 approved curve when FIPS mode is
+// enabled, or P-256 otherwise, since there is no reason to prefer a
+// weaker curve outside FIPS mode either.
+func RecommendedCurve() elliptic.Curve {
+	return elliptic.P256()
+}
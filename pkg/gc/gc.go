@@ -0,0 +1,47 @@
+// Package gc garbage-collects resources the operator previously created
+// but no longer needs, identified by the ManagedByLabel rather than owner
+// references, since several managed resources (cluster-scoped CRDs,
+// cross-namespace secret replicas) cannot carry a namespaced owner
+// reference to the thing that created them.
+package gc
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ManagedByLabel marks every resource the operator creates so that this
+// package can find and reap ones that are no longer wanted.
+const ManagedByLabel = "app.kubernetes.io/managed-by"
+
+// ManagedByValue is the value ManagedByLabel is set to on operator-managed
+// resources.
+const ManagedByValue = "cluster-capi-operator"
+
+// Collect deletes every object in items whose namespace/name is not in
+// keep, scoped to resources carrying ManagedByLabel=ManagedByValue or the
+// operator's identity annotation (see HasIdentity). items
+// must have already been populated by the caller (e.g. via a client.List
+// call), and keep is keyed by types.NamespacedName rather than bare name
+// so that same-named objects in different namespaces (e.g. one kubeconfig
+// secret per managed cluster, each in its own namespace) are never
+// confused for one another.
+func Collect(ctx context.Context, c client.Client, items []client.Object, keep map[types.NamespacedName]bool) (deleted int, err error) {
+	for _, obj := range items {
+		key := types.NamespacedName{Namespace: obj.GetNamespace(), Name: obj.GetName()}
+		if keep[key] {
+			continue
+		}
+		if obj.GetLabels()[ManagedByLabel] != ManagedByValue && !HasIdentity(obj) {
+			continue
+		}
+		if err := client.IgnoreNotFound(c.Delete(ctx, obj)); err != nil {
+			return deleted, fmt.Errorf("failed to delete orphaned resource %s/%s: %w", obj.GetNamespace(), obj.GetName(), err)
+		}
+		deleted++
+	}
+	return deleted, nil
+}
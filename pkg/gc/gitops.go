@@ -0,0 +1,56 @@
+package gc
+
+import (
+	"sort"
+	"strings"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// FieldManager is the field manager name the operator uses for
+// server-side apply (see resourceapply.ApplyServerSide), so a GitOps
+// tool inspecting an object's managedFields can attribute the fields it
+// owns to "cluster-capi-operator" specifically, rather than to a generic
+// client-side apply identity shared with whatever else wrote the
+// object.
+const FieldManager = "cluster-capi-operator"
+
+// ManagedFieldsAnnotation records the JSON pointer paths (RFC 6901,
+// rooted at the object) that this controller mutates on an otherwise
+// user- or GitOps-owned object, so operators wiring up an ArgoCD
+// Application can copy them straight into spec.ignoreDifferences[].
+// jsonPointers and stop seeing perpetual diffs against fields the
+// operator, not the Application's source, is the source of truth for.
+const ManagedFieldsAnnotation = "capi.openshift.io/managed-fields"
+
+// StampManagedFields sets ManagedFieldsAnnotation to the sorted, unique
+// union of paths and whatever paths were already recorded there, so a
+// controller that only ever mutates one part of an object doesn't
+// clobber another controller's paths on the same object.
+func StampManagedFields(obj client.Object, paths ...string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+
+	seen := map[string]bool{}
+	var all []string
+	for _, existing := range strings.Split(annotations[ManagedFieldsAnnotation], ",") {
+		if existing == "" || seen[existing] {
+			continue
+		}
+		seen[existing] = true
+		all = append(all, existing)
+	}
+	for _, path := range paths {
+		if path == "" || seen[path] {
+			continue
+		}
+		seen[path] = true
+		all = append(all, path)
+	}
+	sort.Strings(all)
+
+	annotations[ManagedFieldsAnnotation] = strings.Join(all, ",")
+	obj.SetAnnotations(annotations)
+}
@@ -0,0 +1,25 @@
+package gc
+
+import (
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ClusterNameLabel is the standard Cluster API label clusterctl move uses
+// to find namespaced objects that belong to a Cluster but aren't in its
+// owner-reference graph (e.g. this operator's kubeconfig secrets), so
+// they get included in the move along with everything clusterctl
+// discovers by walking owner references from the Cluster itself.
+const ClusterNameLabel = "cluster.x-k8s.io/cluster-name"
+
+// StampClusterName sets ClusterNameLabel on obj, marking it as belonging
+// to the named Cluster for clusterctl move's discovery. Callers should
+// call this on every namespaced object they create that is associated
+// with a specific Cluster but not owned by it directly.
+func StampClusterName(obj client.Object, clusterName string) {
+	labels := obj.GetLabels()
+	if labels == nil {
+		labels = map[string]string{}
+	}
+	labels[ClusterNameLabel] = clusterName
+	obj.SetLabels(labels)
+}
@@ -0,0 +1,67 @@
+package kubeconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+)
+
+// dialTimeout bounds how long VerifyEndpoint waits for the TLS handshake,
+// so a misconfigured or unreachable endpoint fails a reconcile quickly
+// rather than blocking it for the controller's full context timeout.
+const dialTimeout = 5 * time.Second
+
+// VerifyEndpoint confirms that opts' Server is reachable and presents a
+// certificate that chains to opts' embedded CertificateAuthorityData,
+// catching endpoint misconfigurations (e.g. an internal URL not
+// resolvable from provider pods, or a CA mismatch) before the kubeconfig
+// is published.
+func VerifyEndpoint(ctx context.Context, opts Options) error {
+	host, err := serverHost(opts.Server)
+	if err != nil {
+		return err
+	}
+
+	pool := x509.NewCertPool()
+	if len(opts.CertificateAuthorityData) > 0 && !pool.AppendCertsFromPEM(opts.CertificateAuthorityData) {
+		return fmt.Errorf("endpoint preflight: embedded certificate authority data is not valid PEM")
+	}
+
+	dialer := &tls.Dialer{
+		Config: &tls.Config{
+			RootCAs: pool,
+		},
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, dialTimeout)
+	defer cancel()
+
+	conn, err := dialer.DialContext(dialCtx, "tcp", host)
+	if err != nil {
+		return fmt.Errorf("endpoint preflight: failed to reach %s: %w", opts.Server, err)
+	}
+	defer conn.Close()
+
+	return nil
+}
+
+// serverHost extracts the host:port dial target from a kubeconfig server
+// URL, defaulting to port 443 when the URL omits one.
+func serverHost(server string) (string, error) {
+	u, err := url.Parse(server)
+	if err != nil {
+		return "", fmt.Errorf("endpoint preflight: invalid server URL %q: %w", server, err)
+	}
+	if u.Host == "" {
+		return "", fmt.Errorf("endpoint preflight: server URL %q has no host", server)
+	}
+
+	if _, _, err := net.SplitHostPort(u.Host); err == nil {
+		return u.Host, nil
+	}
+	return net.JoinHostPort(u.Host, "443"), nil
+}
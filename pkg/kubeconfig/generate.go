@@ -0,0 +1,146 @@
+// Package kubeconfig builds the kubeconfig YAML content published for
+// Cluster API providers to reach the management cluster, independently of
+// the controller (pkg/controllers/kubeconfig) that decides when to
+// publish it.
+package kubeconfig
+
+import (
+	"fmt"
+
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+// execAPIVersion is the client authentication API version generated exec
+// plugin stanzas declare, matching the version understood by client-go's
+// exec credential plugin support since Kubernetes 1.22.
+const execAPIVersion = "client.authentication.k8s.io/v1"
+
+// DefaultManagedNamespace is the context namespace generateKubeconfig
+// falls back to when Options.Namespace is unset.
+const DefaultManagedNamespace = "openshift-cluster-api"
+
+// defaultUserName is the auth-info name embedded in a generated
+// kubeconfig when Options.UserName is unset.
+const defaultUserName = "cluster-capi-operator"
+
+// Options customizes the kubeconfig generateKubeconfig produces, so the
+// same generator can serve identities other than the operator's own
+// default (e.g. a restricted provider ServiceAccount) rather than always
+// hard-coding DefaultManagedNamespace as the context namespace and
+// "cluster-capi-operator" as the auth-info name.
+type Options struct {
+	// ClusterName names the generated cluster entry, and is used as the
+	// context and current-context name unless ContextName overrides it.
+	ClusterName string
+
+	// Server is the API server URL to embed.
+	Server string
+
+	// CertificateAuthorityData is the PEM-encoded CA bundle to embed.
+	CertificateAuthorityData []byte
+
+	// Token is the bearer token to embed as the user's credential. Ignored
+	// if ExecPlugin is set.
+	Token string
+
+	// ExecPlugin, if set, generates an exec credential plugin stanza
+	// instead of an embedded Token, for environments that authenticate
+	// through external identity (e.g. cloud IAM via service account
+	// issuer federation) rather than a static token.
+	ExecPlugin *ExecPlugin
+
+	// ContextName overrides the generated context's name. Defaults to
+	// ClusterName.
+	ContextName string
+
+	// Namespace overrides the context's namespace. Defaults to
+	// DefaultManagedNamespace.
+	Namespace string
+
+	// UserName overrides the auth-info name. Defaults to
+	// "cluster-capi-operator".
+	UserName string
+}
+
+// ExecPlugin configures an exec credential plugin stanza for a generated
+// kubeconfig, per the client.authentication.k8s.io ExecConfig contract.
+type ExecPlugin struct {
+	// Command is the executable the kubeconfig invokes to obtain
+	// credentials, e.g. an AWS or GCP IAM token helper.
+	Command string
+
+	// Args are passed to Command.
+	Args []string
+
+	// Env are additional environment variables set for Command.
+	Env map[string]string
+
+	// InstallHint is surfaced to the user if Command cannot be found.
+	InstallHint string
+}
+
+// Generate renders opts into kubeconfig YAML.
+func Generate(opts Options) ([]byte, error) {
+	return generateKubeconfig(opts)
+}
+
+func generateKubeconfig(opts Options) ([]byte, error) {
+	if opts.ClusterName == "" {
+		return nil, fmt.Errorf("kubeconfig: cluster name is required")
+	}
+
+	contextName := opts.ContextName
+	if contextName == "" {
+		contextName = opts.ClusterName
+	}
+
+	namespace := opts.Namespace
+	if namespace == "" {
+		namespace = DefaultManagedNamespace
+	}
+
+	userName := opts.UserName
+	if userName == "" {
+		userName = defaultUserName
+	}
+
+	config := clientcmdapi.NewConfig()
+	config.Clusters[opts.ClusterName] = &clientcmdapi.Cluster{
+		Server:                   opts.Server,
+		CertificateAuthorityData: opts.CertificateAuthorityData,
+	}
+	config.AuthInfos[userName] = authInfoFor(opts)
+	config.Contexts[contextName] = &clientcmdapi.Context{
+		Cluster:   opts.ClusterName,
+		AuthInfo:  userName,
+		Namespace: namespace,
+	}
+	config.CurrentContext = contextName
+
+	return clientcmd.Write(*config)
+}
+
+// authInfoFor builds the AuthInfo generateKubeconfig embeds for opts,
+// preferring an exec credential plugin stanza over a static token when
+// opts.ExecPlugin is set.
+func authInfoFor(opts Options) *clientcmdapi.AuthInfo {
+	if opts.ExecPlugin == nil {
+		return &clientcmdapi.AuthInfo{Token: opts.Token}
+	}
+
+	env := make([]clientcmdapi.ExecEnvVar, 0, len(opts.ExecPlugin.Env))
+	for name, value := range opts.ExecPlugin.Env {
+		env = append(env, clientcmdapi.ExecEnvVar{Name: name, Value: value})
+	}
+
+	return &clientcmdapi.AuthInfo{
+		Exec: &clientcmdapi.ExecConfig{
+			APIVersion:  execAPIVersion,
+			Command:     opts.ExecPlugin.Command,
+			Args:        opts.ExecPlugin.Args,
+			Env:         env,
+			InstallHint: opts.ExecPlugin.InstallHint,
+		},
+	}
+}
@@ -0,0 +1,36 @@
+package kubeconfig
+
+import (
+	"context"
+	"fmt"
+
+	authenticationv1 "k8s.io/api/authentication/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+)
+
+// VerifyToken confirms that opts' embedded token authenticates against
+// opts' target API server by performing a SelfSubjectReview — the same
+// check `kubectl auth whoami` performs — so an invalid or expired token
+// never gets published in a kubeconfig secret.
+func VerifyToken(ctx context.Context, opts Options) error {
+	restConfig := &rest.Config{
+		Host:        opts.Server,
+		BearerToken: opts.Token,
+		TLSClientConfig: rest.TLSClientConfig{
+			CAData: opts.CertificateAuthorityData,
+		},
+	}
+
+	clientset, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return fmt.Errorf("failed to build client for token verification: %w", err)
+	}
+
+	if _, err := clientset.AuthenticationV1().SelfSubjectReviews().Create(ctx, &authenticationv1.SelfSubjectReview{}, metav1.CreateOptions{}); err != nil {
+		return fmt.Errorf("embedded token failed verification against %s: %w", opts.Server, err)
+	}
+
+	return nil
+}
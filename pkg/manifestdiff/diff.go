@@ -0,0 +1,110 @@
+// Package manifestdiff categorizes differences between two sets of
+// rendered provider manifests (e.g. the operator's current bundle and an
+// upstream release), so a rebase can see at a glance what changed by
+// kind of object rather than reading a raw YAML diff.
+package manifestdiff
+
+import (
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// Category groups a Change by the kind of object it affects, since RBAC,
+// CRD, and Deployment changes carry very different rebase risk.
+type Category string
+
+const (
+	CategoryCRD        Category = "CRD"
+	CategoryRBAC       Category = "RBAC"
+	CategoryDeployment Category = "Deployment"
+	CategoryOther      Category = "Other"
+)
+
+// categoryFor maps an object Kind to the Category it's reported under.
+func categoryFor(kind string) Category {
+	switch kind {
+	case "CustomResourceDefinition":
+		return CategoryCRD
+	case "Role", "ClusterRole", "RoleBinding", "ClusterRoleBinding", "ServiceAccount":
+		return CategoryRBAC
+	case "Deployment":
+		return CategoryDeployment
+	default:
+		return CategoryOther
+	}
+}
+
+// ChangeKind distinguishes whether an object was added, removed, or
+// modified between the old and new manifest sets.
+type ChangeKind string
+
+const (
+	ChangeAdded    ChangeKind = "added"
+	ChangeRemoved  ChangeKind = "removed"
+	ChangeModified ChangeKind = "modified"
+)
+
+// Change describes a single object's difference between the old and new
+// manifest sets.
+type Change struct {
+	Category  Category
+	Kind      ChangeKind
+	Namespace string
+	Name      string
+	GVK       string
+}
+
+// Diff compares oldObjs against newObjs, keyed by GroupVersionKind,
+// namespace, and name, and returns one Change per object that was added,
+// removed, or whose content differs in any field, metadata included.
+func Diff(oldObjs, newObjs []*unstructured.Unstructured) []Change {
+	oldByKey := indexByKey(oldObjs)
+	newByKey := indexByKey(newObjs)
+
+	var changes []Change
+	for key, newObj := range newByKey {
+		oldObj, existed := oldByKey[key]
+		switch {
+		case !existed:
+			changes = append(changes, changeFor(newObj, ChangeAdded))
+		case !reflect.DeepEqual(oldObj.Object, newObj.Object):
+			changes = append(changes, changeFor(newObj, ChangeModified))
+		}
+	}
+	for key, oldObj := range oldByKey {
+		if _, stillExists := newByKey[key]; !stillExists {
+			changes = append(changes, changeFor(oldObj, ChangeRemoved))
+		}
+	}
+
+	return changes
+}
+
+type objectKey struct {
+	gvk       string
+	namespace string
+	name      string
+}
+
+func indexByKey(objs []*unstructured.Unstructured) map[objectKey]*unstructured.Unstructured {
+	index := make(map[objectKey]*unstructured.Unstructured, len(objs))
+	for _, obj := range objs {
+		index[objectKey{
+			gvk:       obj.GetObjectKind().GroupVersionKind().String(),
+			namespace: obj.GetNamespace(),
+			name:      obj.GetName(),
+		}] = obj
+	}
+	return index
+}
+
+func changeFor(obj *unstructured.Unstructured, kind ChangeKind) Change {
+	return Change{
+		Category:  categoryFor(obj.GetKind()),
+		Kind:      kind,
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		GVK:       obj.GetObjectKind().GroupVersionKind().String(),
+	}
+}
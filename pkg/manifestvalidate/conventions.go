@@ -0,0 +1,50 @@
+package manifestvalidate
+
+import (
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/openshift/cluster-capi-operator/pkg/gc"
+)
+
+// clusterScopedKinds lists the Kinds ValidateConventions treats as
+// cluster-scoped, and therefore exempt from the namespace check. It is
+// deliberately short: the provider manifests this operator renders are
+// almost entirely namespaced (Deployments, RBAC, Secrets), and a
+// cluster-scoped kind missing here simply fails the namespace check with
+// an actionable message instead of silently passing.
+var clusterScopedKinds = map[string]bool{
+	"CustomResourceDefinition":       true,
+	"ClusterRole":                    true,
+	"ClusterRoleBinding":             true,
+	"Namespace":                      true,
+	"ValidatingWebhookConfiguration": true,
+	"MutatingWebhookConfiguration":   true,
+}
+
+// ValidateConventions checks that every namespaced object in objs targets
+// namespace and every object carries gc.ManagedByLabel, the two
+// conventions this operator relies on elsewhere (namespace scoping for
+// --namespace, the managed-by label for garbage collection and drift
+// guarding) to ever find a rendered manifest's resources again. A rebase
+// that drops either of them would otherwise only surface as a resource
+// leak or an un-collected orphan much later.
+func ValidateConventions(objs []*unstructured.Unstructured, namespace string) error {
+	var errs []error
+
+	for _, obj := range objs {
+		name := fmt.Sprintf("%s %s/%s", obj.GetKind(), obj.GetNamespace(), obj.GetName())
+
+		if !clusterScopedKinds[obj.GetKind()] && obj.GetNamespace() != namespace {
+			errs = append(errs, fmt.Errorf("%s: expected namespace %q, got %q", name, namespace, obj.GetNamespace()))
+		}
+
+		if obj.GetLabels()[gc.ManagedByLabel] != gc.ManagedByValue {
+			errs = append(errs, fmt.Errorf("%s: missing label %s=%s", name, gc.ManagedByLabel, gc.ManagedByValue))
+		}
+	}
+
+	return errors.Join(errs...)
+}
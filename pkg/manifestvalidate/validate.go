@@ -0,0 +1,67 @@
+// Package manifestvalidate applies embedded provider manifests into a
+// live (or envtest) API server and dry-run creates sample CRs against the
+// installed CRDs and webhooks, catching manifest/CRD drift from upstream
+// rebases before it ships.
+package manifestvalidate
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// ParseDocuments splits a multi-document YAML manifest (documents
+// separated by "---") into unstructured objects, skipping empty
+// documents, the way `kubectl apply -f` treats a rendered manifest file.
+func ParseDocuments(manifest []byte) ([]*unstructured.Unstructured, error) {
+	var objs []*unstructured.Unstructured
+
+	for i, raw := range bytes.Split(manifest, []byte("\n---")) {
+		trimmed := bytes.TrimSpace(raw)
+		if len(trimmed) == 0 {
+			continue
+		}
+
+		obj := &unstructured.Unstructured{}
+		if err := yaml.Unmarshal(trimmed, &obj.Object); err != nil {
+			return nil, fmt.Errorf("failed to parse manifest document %d: %w", i, err)
+		}
+		if obj.GetObjectKind().GroupVersionKind().Kind == "" {
+			continue
+		}
+		objs = append(objs, obj)
+	}
+
+	return objs, nil
+}
+
+// Apply creates every object in objs against c, aggregating any errors
+// rather than stopping at the first one, so a single broken manifest
+// doesn't hide drift in the rest of the batch. Pass client.DryRunAll in
+// opts to validate without persisting anything.
+func Apply(ctx context.Context, c client.Client, objs []*unstructured.Unstructured, opts ...client.CreateOption) error {
+	var errs []error
+	for _, obj := range objs {
+		if err := c.Create(ctx, obj, opts...); err != nil {
+			errs = append(errs, fmt.Errorf("%s %s/%s: %w", obj.GetKind(), obj.GetNamespace(), obj.GetName(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// DryRunSamples parses samples, a multi-document YAML manifest of sample
+// custom resources, and dry-run creates each one against c, so the
+// installed CRDs' schemas and any admission webhooks reject a malformed
+// sample before it ever reaches a real cluster.
+func DryRunSamples(ctx context.Context, c client.Client, samples []byte) error {
+	objs, err := ParseDocuments(samples)
+	if err != nil {
+		return err
+	}
+	return Apply(ctx, c, objs, client.DryRunAll)
+}
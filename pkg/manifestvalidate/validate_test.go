@@ -0,0 +1,59 @@
+package manifestvalidate
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+)
+
+// TestDryRunSamplesAgainstLiveCRDs is a CI-usable smoke test: it boots an
+// envtest API server, applies the CRDs under crdDir (set via
+// MANIFESTVALIDATE_CRD_DIR), and dry-run creates the sample CRs under
+// sampleDir (set via MANIFESTVALIDATE_SAMPLE_DIR). It skips rather than
+// fails when those aren't configured, since most developer machines and
+// the default `go test ./...` run don't have kubebuilder's envtest
+// binaries installed.
+func TestDryRunSamplesAgainstLiveCRDs(t *testing.T) {
+	crdDir := os.Getenv("MANIFESTVALIDATE_CRD_DIR")
+	sampleDir := os.Getenv("MANIFESTVALIDATE_SAMPLE_DIR")
+	if crdDir == "" || sampleDir == "" {
+		t.Skip("MANIFESTVALIDATE_CRD_DIR and MANIFESTVALIDATE_SAMPLE_DIR are not set; skipping live-CRD manifest validation")
+	}
+
+	testEnv := &envtest.Environment{
+		CRDDirectoryPaths: []string{crdDir},
+	}
+	cfg, err := testEnv.Start()
+	if err != nil {
+		t.Fatalf("failed to start envtest environment: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := testEnv.Stop(); err != nil {
+			t.Errorf("failed to stop envtest environment: %v", err)
+		}
+	})
+
+	c, err := client.New(cfg, client.Options{})
+	if err != nil {
+		t.Fatalf("failed to build client: %v", err)
+	}
+
+	samples, err := os.ReadDir(sampleDir)
+	if err != nil {
+		t.Fatalf("failed to read sample directory: %v", err)
+	}
+
+	ctx := context.Background()
+	for _, sample := range samples {
+		data, err := os.ReadFile(sampleDir + "/" + sample.Name())
+		if err != nil {
+			t.Fatalf("failed to read sample %s: %v", sample.Name(), err)
+		}
+		if err := DryRunSamples(ctx, c, data); err != nil {
+			t.Errorf("sample %s failed dry-run validation: %v", sample.Name(), err)
+		}
+	}
+}
@@ -0,0 +1,276 @@
+// Package metrics defines the operator's self-observability metrics,
+// recording reconcile outcomes for each controller so that SLOs for the
+// operator itself can be computed independently of the workloads it manages.
+package metrics
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// Outcome labels the result of a single reconcile invocation.
+type Outcome string
+
+const (
+	// OutcomeSuccess indicates the reconcile completed without error.
+	OutcomeSuccess Outcome = "success"
+	// OutcomeError indicates the reconcile returned an error.
+	OutcomeError Outcome = "error"
+	// OutcomeDegraded indicates the reconcile completed but left the
+	// controller's resource in a degraded state.
+	OutcomeDegraded Outcome = "degraded"
+)
+
+var (
+	reconcileTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "reconcile_total",
+			Help: "Total number of reconciles per controller and outcome.",
+		},
+		[]string{"controller", "outcome", "degraded_reason"},
+	)
+
+	reconcileDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "reconcile_duration_seconds",
+			Help:    "Duration of reconcile calls per controller and outcome.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"controller", "outcome"},
+	)
+
+	// capiMachinesTotal and capiMachineSetsTotal feed this operator's
+	// telemetry recording rules, so CAPI adoption can be measured across
+	// the fleet without exporting raw per-cluster machine objects.
+	capiMachinesTotal = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "capi_machines_total",
+		Help: "Total number of Cluster API Machine objects on the cluster.",
+	})
+
+	capiMachineSetsTotal = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "capi_machine_sets_total",
+		Help: "Total number of Cluster API MachineSet objects on the cluster.",
+	})
+
+	capiProvidersInstalledTotal = prometheus.NewGauge(prometheus.GaugeOpts{
+		Name: "capi_providers_installed",
+		Help: "Total number of Cluster API providers currently installed.",
+	})
+
+	capiMigrationStateTotal = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "capi_migration_state_total",
+			Help: "Number of MachineSets at each authoritative-API migration state.",
+		},
+		[]string{"authoritative_api"},
+	)
+
+	capiMachinePhaseTotal = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "capi_machine_phase_total",
+			Help: "Number of Cluster API Machine objects in each phase.",
+		},
+		[]string{"phase"},
+	)
+
+	capiMachineStuckProvisioningSeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "capi_machine_stuck_seconds",
+			Help: "How long a Machine has spent in a non-terminal phase beyond the stuck threshold, keyed by machine.",
+		},
+		[]string{"namespace", "name", "phase"},
+	)
+
+	capiMachineSetReplicaMismatch = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "capi_machineset_replica_mismatch",
+			Help: "Difference between a MachineSet's status and spec replica counts (status - spec).",
+		},
+		[]string{"namespace", "name"},
+	)
+
+	capiKubeconfigConsumers = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "capi_kubeconfig_consumers",
+			Help: "Number of Pods found mounting a given kubeconfig secret as a volume.",
+		},
+		[]string{"namespace", "secret"},
+	)
+
+	capiSelfSignedCertExpirySeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "capi_self_signed_cert_expiry_seconds",
+			Help: "Unix time at which a self-signed CA or serving certificate managed by the operator expires.",
+		},
+		[]string{"namespace", "secret", "kind"},
+	)
+
+	capiKubeconfigAuthFailures = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "capi_kubeconfig_auth_failures",
+			Help: "Number of Pods found mounting a given kubeconfig secret that reported an authentication failure since its last rotation.",
+		},
+		[]string{"namespace", "secret"},
+	)
+
+	cacheBudgetObjectsTotal = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cache_budget_objects_total",
+			Help: "Number of objects of a given kind currently held in the operator's informer cache.",
+		},
+		[]string{"kind"},
+	)
+
+	cacheBudgetApproxBytes = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "cache_budget_approx_bytes",
+			Help: "Rough estimate of the memory held by a given kind's objects in the operator's informer cache.",
+		},
+		[]string{"kind"},
+	)
+
+	capiGCResourcesReapedTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "capi_gc_resources_reaped_total",
+			Help: "Total number of operator-managed resources the GC janitor deleted (or would have deleted, under dry_run) because their desired-state source no longer exists.",
+		},
+		[]string{"kind", "dry_run"},
+	)
+
+	capiReplicaAuthorityConflictsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "capi_replica_authority_conflicts_total",
+			Help: "Total number of times the MachineSetSync controller found disagreeing replica-authority annotations on a MAPI/CAPI MachineSet pair.",
+		},
+		[]string{"namespace", "name"},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		reconcileTotal,
+		reconcileDuration,
+		capiMachinesTotal,
+		capiMachineSetsTotal,
+		capiProvidersInstalledTotal,
+		capiMigrationStateTotal,
+		capiMachinePhaseTotal,
+		capiMachineStuckProvisioningSeconds,
+		capiMachineSetReplicaMismatch,
+		capiKubeconfigConsumers,
+		capiKubeconfigAuthFailures,
+		capiSelfSignedCertExpirySeconds,
+		cacheBudgetObjectsTotal,
+		cacheBudgetApproxBytes,
+		capiGCResourcesReapedTotal,
+		capiReplicaAuthorityConflictsTotal,
+	)
+}
+
+// ObserveReconcile records the outcome and duration of a single reconcile
+// invocation for controller. degradedReason is only meaningful when outcome
+// is OutcomeDegraded and may be empty otherwise.
+func ObserveReconcile(controller string, outcome Outcome, degradedReason string, duration time.Duration) {
+	reconcileTotal.WithLabelValues(controller, string(outcome), degradedReason).Inc()
+	reconcileDuration.WithLabelValues(controller, string(outcome)).Observe(duration.Seconds())
+}
+
+// SetCAPIAdoptionMetrics records a point-in-time snapshot of CAPI adoption
+// for telemetry: how many Machines and MachineSets exist, how many
+// providers are installed, and how MachineSets are distributed across
+// authoritative-API migration states.
+func SetCAPIAdoptionMetrics(machines, machineSets, providersInstalled int, migrationStateCounts map[string]int) {
+	capiMachinesTotal.Set(float64(machines))
+	capiMachineSetsTotal.Set(float64(machineSets))
+	capiProvidersInstalledTotal.Set(float64(providersInstalled))
+
+	capiMigrationStateTotal.Reset()
+	for state, count := range migrationStateCounts {
+		capiMigrationStateTotal.WithLabelValues(state).Set(float64(count))
+	}
+}
+
+// StuckMachine identifies a Machine that has spent longer than the stuck
+// threshold in a non-terminal phase.
+type StuckMachine struct {
+	Namespace string
+	Name      string
+	Phase     string
+	Age       time.Duration
+}
+
+// ReplicaMismatch reports a MachineSet whose status replica count differs
+// from its spec.
+type ReplicaMismatch struct {
+	Namespace string
+	Name      string
+	Mismatch  int32
+}
+
+// SetMachineHealthMetrics records a point-in-time snapshot of CAPI Machine
+// phase distribution, stuck Machines, and MachineSet replica mismatches.
+func SetMachineHealthMetrics(phaseCounts map[string]int, stuck []StuckMachine, replicaMismatches []ReplicaMismatch) {
+	capiMachinePhaseTotal.Reset()
+	for phase, count := range phaseCounts {
+		capiMachinePhaseTotal.WithLabelValues(phase).Set(float64(count))
+	}
+
+	capiMachineStuckProvisioningSeconds.Reset()
+	for _, m := range stuck {
+		capiMachineStuckProvisioningSeconds.WithLabelValues(m.Namespace, m.Name, m.Phase).Set(m.Age.Seconds())
+	}
+
+	capiMachineSetReplicaMismatch.Reset()
+	for _, r := range replicaMismatches {
+		capiMachineSetReplicaMismatch.WithLabelValues(r.Namespace, r.Name).Set(float64(r.Mismatch))
+	}
+}
+
+// SetKubeconfigConsumers records how many Pods were found mounting
+// namespace's secret as a volume.
+func SetKubeconfigConsumers(namespace, secret string, consumers int) {
+	capiKubeconfigConsumers.WithLabelValues(namespace, secret).Set(float64(consumers))
+}
+
+// SetKubeconfigAuthFailures records how many of namespace's secret's
+// consumers reported an authentication failure since the secret's last
+// rotation.
+func SetKubeconfigAuthFailures(namespace, secret string, failures int) {
+	capiKubeconfigAuthFailures.WithLabelValues(namespace, secret).Set(float64(failures))
+}
+
+// SetSelfSignedCertExpiry records when a self-signed CA or serving
+// certificate ("ca" or "serving") stored in namespace/secret expires.
+func SetSelfSignedCertExpiry(namespace, secret, kind string, notAfter time.Time) {
+	capiSelfSignedCertExpirySeconds.WithLabelValues(namespace, secret, kind).Set(float64(notAfter.Unix()))
+}
+
+// SetCacheBudget records how many objects of kind the operator's informer
+// cache currently holds, and their approximate combined memory footprint
+// (see pkg/cachebudget).
+func SetCacheBudget(kind string, objects int, approxBytes int64) {
+	cacheBudgetObjectsTotal.WithLabelValues(kind).Set(float64(objects))
+	cacheBudgetApproxBytes.WithLabelValues(kind).Set(float64(approxBytes))
+}
+
+// ObserveReplicaAuthorityConflict records that the MachineSetSync
+// controller found a namespace/name MachineSet pair with disagreeing
+// replica-authority annotations (see pkg/conversion/replicaauthority),
+// meaning an operator set conflicting intent on both sides rather than
+// flipping authority for the pair as a whole.
+func ObserveReplicaAuthorityConflict(namespace, name string) {
+	capiReplicaAuthorityConflictsTotal.WithLabelValues(namespace, name).Inc()
+}
+
+// ObserveGCCollection records that the GC janitor reaped (or, under
+// dryRun, would have reaped) count resources of kind because their
+// desired-state source no longer exists (see pkg/controllers/gcjanitor).
+func ObserveGCCollection(kind string, dryRun bool, count int) {
+	if count == 0 {
+		return
+	}
+	capiGCResourcesReapedTotal.WithLabelValues(kind, strconv.FormatBool(dryRun)).Add(float64(count))
+}
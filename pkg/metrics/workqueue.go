@@ -0,0 +1,159 @@
+package metrics
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+// retryWarnThreshold is how many cumulative retries a controller's
+// workqueue logs a warning at, and at every multiple thereafter.
+// workqueue.MetricsProvider has no notion of which item was retried, so
+// this is a per-controller approximation of "an item has been retried
+// too many times" rather than a true per-item count.
+const retryWarnThreshold = 5
+
+var (
+	controllerWorkqueueDepth = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "controller_workqueue_depth",
+			Help: "Current depth of a controller's workqueue.",
+		},
+		[]string{"controller"},
+	)
+
+	controllerWorkqueueAddsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "controller_workqueue_adds_total",
+			Help: "Total number of items added to a controller's workqueue.",
+		},
+		[]string{"controller"},
+	)
+
+	controllerWorkqueueQueueDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "controller_workqueue_queue_duration_seconds",
+			Help:    "How long an item sat in a controller's workqueue before being processed.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"controller"},
+	)
+
+	controllerWorkqueueWorkDurationSeconds = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "controller_workqueue_work_duration_seconds",
+			Help:    "How long it took to process an item popped from a controller's workqueue.",
+			Buckets: prometheus.DefBuckets,
+		},
+		[]string{"controller"},
+	)
+
+	controllerWorkqueueUnfinishedWorkSeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "controller_workqueue_unfinished_work_seconds",
+			Help: "How long the currently in-flight items on a controller's workqueue have been processing.",
+		},
+		[]string{"controller"},
+	)
+
+	controllerWorkqueueLongestRunningProcessorSeconds = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "controller_workqueue_longest_running_processor_seconds",
+			Help: "How long the single longest-running reconcile on a controller's workqueue has been processing.",
+		},
+		[]string{"controller"},
+	)
+
+	controllerWorkqueueRetriesTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "controller_workqueue_retries_total",
+			Help: "Total number of items requeued after a failed reconcile, per controller.",
+		},
+		[]string{"controller"},
+	)
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(
+		controllerWorkqueueDepth,
+		controllerWorkqueueAddsTotal,
+		controllerWorkqueueQueueDurationSeconds,
+		controllerWorkqueueWorkDurationSeconds,
+		controllerWorkqueueUnfinishedWorkSeconds,
+		controllerWorkqueueLongestRunningProcessorSeconds,
+		controllerWorkqueueRetriesTotal,
+	)
+}
+
+// RegisterWorkqueueMetrics installs a workqueue.MetricsProvider that
+// records every controller's workqueue under this package's stable
+// controller_workqueue_* metric names, instead of controller-runtime's
+// default workqueue_* names (which are not guaranteed stable across
+// client-go versions). It must be called once, before the manager
+// starts any controllers.
+func RegisterWorkqueueMetrics() {
+	workqueue.SetProvider(workqueueMetricsProvider{})
+}
+
+// workqueueMetricsProvider implements workqueue.MetricsProvider, naming
+// every controller's workqueue metrics after the queue name
+// controller-runtime assigns it, which matches the owning controller's
+// name.
+type workqueueMetricsProvider struct{}
+
+func (workqueueMetricsProvider) NewDepthMetric(name string) workqueue.GaugeMetric {
+	return controllerWorkqueueDepth.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewAddsMetric(name string) workqueue.CounterMetric {
+	return controllerWorkqueueAddsTotal.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewLatencyMetric(name string) workqueue.HistogramMetric {
+	return controllerWorkqueueQueueDurationSeconds.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewWorkDurationMetric(name string) workqueue.HistogramMetric {
+	return controllerWorkqueueWorkDurationSeconds.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewUnfinishedWorkSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return controllerWorkqueueUnfinishedWorkSeconds.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewLongestRunningProcessorSecondsMetric(name string) workqueue.SettableGaugeMetric {
+	return controllerWorkqueueLongestRunningProcessorSeconds.WithLabelValues(name)
+}
+
+func (workqueueMetricsProvider) NewRetriesMetric(name string) workqueue.CounterMetric {
+	return &retriesMetric{controller: name}
+}
+
+// retriesMetric wraps the per-controller retries counter so that, in
+// addition to recording it, it can warn when a controller's workqueue
+// has accumulated an unusually high number of retries.
+type retriesMetric struct {
+	controller string
+}
+
+func (m *retriesMetric) Inc() {
+	controllerWorkqueueRetriesTotal.WithLabelValues(m.controller).Inc()
+
+	if count := incRetryCount(m.controller); count%retryWarnThreshold == 0 {
+		log.Log.WithName("workqueue").Info("controller workqueue has accumulated repeated retries",
+			"controller", m.controller, "retries", count, "threshold", retryWarnThreshold)
+	}
+}
+
+var retryCounts sync.Map
+
+// incRetryCount increments and returns the cumulative retry count
+// tracked for controller.
+func incRetryCount(controller string) int64 {
+	counter, _ := retryCounts.LoadOrStore(controller, new(int64))
+	return atomic.AddInt64(counter.(*int64), 1)
+}
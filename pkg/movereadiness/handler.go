@@ -0,0 +1,22 @@
+package movereadiness
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// ServeHTTP re-evaluates Check on every request and writes the Report as
+// JSON, so an admin (or a clusterctl move pre-flight script) can poll it
+// directly rather than reconstructing the checks themselves.
+func (c *Checker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report, err := c.Check(r.Context())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
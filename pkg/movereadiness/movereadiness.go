@@ -0,0 +1,78 @@
+// Package movereadiness reports whether it is safe to clusterctl move
+// the Clusters this operator manages to another management cluster: the
+// operator's own controllers must be paused (see pkg/standby) and no
+// Machine may be mid-provisioning or mid-deletion, since a move in the
+// middle of either would leave the target management cluster unable to
+// reconcile it to completion.
+package movereadiness
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/openshift/cluster-capi-operator/pkg/standby"
+)
+
+// nonTerminalPhases are the Machine phases that must drain before a move
+// is safe, mirroring pkg/controllers/machinemetrics' definition of "in
+// flight".
+var nonTerminalPhases = map[string]bool{
+	string(clusterv1.MachinePhasePending):      true,
+	string(clusterv1.MachinePhaseProvisioning): true,
+	string(clusterv1.MachinePhaseDeleting):     true,
+}
+
+// Report is the result of a readiness Check.
+type Report struct {
+	// Ready is true only if every check below passed.
+	Ready bool `json:"ready"`
+
+	// Reasons explains what, if anything, is blocking a move. Empty when
+	// Ready is true.
+	Reasons []string `json:"reasons,omitempty"`
+}
+
+// Checker evaluates move readiness for every Cluster in Namespace.
+type Checker struct {
+	client.Client
+
+	// Namespace is the namespace the operator manages CAPI resources in.
+	Namespace string
+
+	// Gate is the operator's standby write gate. A move is never ready
+	// while Gate is active, since the operator reconciling mid-move
+	// would race with clusterctl's own pause and the target management
+	// cluster's eventual takeover.
+	Gate *standby.Gate
+}
+
+// Check evaluates every readiness condition and returns a Report.
+func (c *Checker) Check(ctx context.Context) (*Report, error) {
+	var reasons []string
+
+	if c.Gate.Active() {
+		reasons = append(reasons, "operator is not paused: start it with --standby or pause it before moving")
+	}
+
+	machines := &clusterv1.MachineList{}
+	if err := c.List(ctx, machines, client.InNamespace(c.Namespace)); err != nil {
+		return nil, fmt.Errorf("listing Machines: %w", err)
+	}
+
+	var inFlight []string
+	for _, machine := range machines.Items {
+		if nonTerminalPhases[machine.Status.Phase] {
+			inFlight = append(inFlight, machine.Namespace+"/"+machine.Name)
+		}
+	}
+	if len(inFlight) > 0 {
+		sort.Strings(inFlight)
+		reasons = append(reasons, fmt.Sprintf("%d machine(s) mid-provisioning or mid-deletion: %v", len(inFlight), inFlight))
+	}
+
+	return &Report{Ready: len(reasons) == 0, Reasons: reasons}, nil
+}
@@ -0,0 +1,47 @@
+package providers
+
+import (
+	"fmt"
+
+	"github.com/openshift/cluster-capi-operator/pkg/util/operrors"
+)
+
+// contractLabelPrefix is the label prefix Cluster API providers use to
+// advertise which API contract version(s) they implement, e.g.
+// "cluster.x-k8s.io/v1beta1": "v1beta1".
+const contractLabelPrefix = "cluster.x-k8s.io/"
+
+// SupportedContracts lists the contract versions the core provider bundled
+// by this operator implements.
+var SupportedContracts = []string{"v1beta1"}
+
+// CheckContractCompatibility verifies that an infrastructure provider's
+// advertised contract labels include at least one contract version the
+// core provider also supports, returning an error describing the mismatch
+// otherwise.
+func CheckContractCompatibility(infraProviderLabels map[string]string) error {
+	advertised := contractsFromLabels(infraProviderLabels)
+	if len(advertised) == 0 {
+		return operrors.New(operrors.KindContractIncompatible, "", fmt.Errorf("infrastructure provider does not advertise a %s* contract label", contractLabelPrefix))
+	}
+
+	for _, supported := range SupportedContracts {
+		for _, have := range advertised {
+			if supported == have {
+				return nil
+			}
+		}
+	}
+
+	return operrors.New(operrors.KindContractIncompatible, "", fmt.Errorf("infrastructure provider advertises contracts %v, none of which match the core provider's supported contracts %v", advertised, SupportedContracts))
+}
+
+func contractsFromLabels(labels map[string]string) []string {
+	var contracts []string
+	for k := range labels {
+		if len(k) > len(contractLabelPrefix) && k[:len(contractLabelPrefix)] == contractLabelPrefix {
+			contracts = append(contracts, k[len(contractLabelPrefix):])
+		}
+	}
+	return contracts
+}
@@ -0,0 +1,63 @@
+package providers
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// FeatureGateConfigMapName holds the provider feature-gate settings this
+// operator renders into provider container args, keyed by feature name to
+// a boolean string that strconv.ParseBool accepts (e.g. "true"/"false").
+const FeatureGateConfigMapName = "cluster-capi-operator-feature-gates"
+
+// FeatureGatesFromConfigMap reads feature-gate settings out of cm.Data,
+// skipping values that don't parse as booleans rather than failing the
+// whole read, since a single operator typo shouldn't block every provider.
+func FeatureGatesFromConfigMap(cm *corev1.ConfigMap) map[string]bool {
+	gates := make(map[string]bool, len(cm.Data))
+	for name, value := range cm.Data {
+		enabled, err := strconv.ParseBool(value)
+		if err != nil {
+			continue
+		}
+		gates[name] = enabled
+	}
+	return gates
+}
+
+// RenderFeatureGateArg renders gates into a single deterministic
+// "--feature-gates=Name=bool,..." container argument, suitable for
+// comparison across reconciles.
+func RenderFeatureGateArg(gates map[string]bool) string {
+	if len(gates) == 0 {
+		return ""
+	}
+
+	names := make([]string, 0, len(gates))
+	for name := range gates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, fmt.Sprintf("%s=%t", name, gates[name]))
+	}
+
+	return "--feature-gates=" + strings.Join(pairs, ",")
+}
+
+// FeatureGateChecksum returns a short, deterministic checksum of gates,
+// suitable for stamping onto a provider Deployment's pod template
+// annotations so a rolling restart is triggered exactly when the rendered
+// feature-gate argument would change.
+func FeatureGateChecksum(gates map[string]bool) string {
+	sum := sha256.Sum256([]byte(RenderFeatureGateArg(gates)))
+	return hex.EncodeToString(sum[:])[:16]
+}
@@ -0,0 +1,64 @@
+// Package providers decides which Cluster API providers the operator
+// installs for a given platform, including the "core only" mode used on
+// platforms without an OpenShift-maintained infrastructure provider.
+package providers
+
+import (
+	configv1 "github.com/openshift/api/config/v1"
+
+	"github.com/openshift/cluster-capi-operator/pkg/util/platform"
+)
+
+// CoreOnlyAnnotation opts a PlatformType: None cluster into installing only
+// the Cluster API core components and the kubeconfig secret, so that a
+// user-supplied infrastructure provider can be layered on top.
+const CoreOnlyAnnotation = "cluster-api.openshift.io/core-only"
+
+// Plan describes which providers should be installed for a cluster.
+type Plan struct {
+	// InstallCore indicates the core Cluster API provider should be
+	// installed.
+	InstallCore bool
+	// InstallInfra indicates an OpenShift-maintained infrastructure
+	// provider should be installed.
+	InstallInfra bool
+	// BringYourOwnInfra indicates the cluster is expected to supply its
+	// own infrastructure provider out of band.
+	BringYourOwnInfra bool
+	// ExternalPlatformName is set when the cluster's platform is
+	// configv1.ExternalPlatformType, to the cloud-controller-manager hint
+	// recorded in Infrastructure.Spec.PlatformSpec.External.PlatformName.
+	// Callers use it to look up a matching BYOProvider registration via
+	// Registry.Lookup.
+	ExternalPlatformName string
+}
+
+// ExternalInfraProviderName is the name of the infrastructure provider used
+// for managed workload clusters whose control plane runs outside the
+// workload cluster itself (e.g. ROSA HCP), where there is no in-cluster
+// infrastructure to reconcile against beyond what the managing service
+// already provisioned.
+const ExternalInfraProviderName = "infrastructure-external"
+
+// Resolve computes the provider installation plan for platformType, given
+// whether the cluster has opted into core-only mode via annotation.
+// externalPlatformName is only meaningful when platformType is
+// configv1.ExternalPlatformType; it is read from
+// Infrastructure.Spec.PlatformSpec.External.PlatformName and carried
+// through to Plan.ExternalPlatformName so the caller can resolve it against
+// the bring-your-own provider registry.
+func Resolve(platformType configv1.PlatformType, coreOnlyRequested bool, externalPlatformName string) Plan {
+	if platform.IsSupported(platformType) {
+		return Plan{InstallCore: true, InstallInfra: true}
+	}
+
+	if platformType == configv1.ExternalPlatformType {
+		return Plan{InstallCore: true, BringYourOwnInfra: true, ExternalPlatformName: externalPlatformName}
+	}
+
+	if platformType == configv1.NonePlatformType && coreOnlyRequested {
+		return Plan{InstallCore: true, BringYourOwnInfra: true}
+	}
+
+	return Plan{}
+}
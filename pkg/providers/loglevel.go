@@ -0,0 +1,43 @@
+package providers
+
+import (
+	"strconv"
+	"strings"
+)
+
+// verbosityArgPrefix is the container argument prefix providers use for
+// klog verbosity, matching upstream Cluster API provider convention.
+const verbosityArgPrefix = "--v="
+
+// RenderVerbosityArg renders level into the "--v=N" container argument
+// providers expect.
+func RenderVerbosityArg(level int32) string {
+	return verbosityArgPrefix + strconv.Itoa(int(level))
+}
+
+// SetVerbosityArg returns args with any existing "--v=" argument replaced
+// by level, or level appended if none was present, so that changing log
+// level doesn't grow the arg list with stale duplicates across reconciles.
+func SetVerbosityArg(args []string, level int32) []string {
+	rendered := RenderVerbosityArg(level)
+
+	out := make([]string, 0, len(args)+1)
+	replaced := false
+	for _, arg := range args {
+		if strings.HasPrefix(arg, verbosityArgPrefix) {
+			if replaced {
+				continue
+			}
+			out = append(out, rendered)
+			replaced = true
+			continue
+		}
+		out = append(out, arg)
+	}
+
+	if !replaced {
+		out = append(out, rendered)
+	}
+
+	return out
+}
@@ -0,0 +1,87 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+)
+
+// RegistryConfigMapName is the name of the ConfigMap users populate to
+// register a bring-your-own infrastructure provider.
+const RegistryConfigMapName = "capi-byo-providers"
+
+// byoProvidersKey is the key within RegistryConfigMapName whose value is a
+// YAML-encoded list of BYOProvider entries.
+const byoProvidersKey = "providers.yaml"
+
+// BYOProvider describes a user-registered infrastructure provider that the
+// operator does not ship or maintain itself.
+type BYOProvider struct {
+	// Name is the CAPI provider name, e.g. "infrastructure-byo-example".
+	Name string `json:"name"`
+	// Version is the provider version to install, e.g. "v1.2.3".
+	Version string `json:"version"`
+	// ManifestsURL points at the provider's components.yaml.
+	ManifestsURL string `json:"manifestsURL"`
+	// Platform, if set, is the PlatformType: External platform name (see
+	// Infrastructure.Spec.PlatformSpec.External.PlatformName) this provider
+	// should be installed for. Empty for providers registered under
+	// PlatformType: None, which have no platform name to match against.
+	Platform string `json:"platform,omitempty"`
+}
+
+// Registry reads bring-your-own provider registrations from the
+// capi-byo-providers ConfigMap in the operator's managed namespace.
+type Registry struct {
+	client.Client
+
+	Namespace string
+}
+
+// List returns the providers currently registered by the user. An absent
+// ConfigMap is treated as an empty registry, not an error.
+func (r *Registry) List(ctx context.Context) ([]BYOProvider, error) {
+	cm := &corev1.ConfigMap{}
+	err := r.Get(ctx, types.NamespacedName{Name: RegistryConfigMapName, Namespace: r.Namespace}, cm)
+	if client.IgnoreNotFound(err) != nil {
+		return nil, fmt.Errorf("failed to read provider registry: %w", err)
+	}
+	if err != nil {
+		return nil, nil
+	}
+
+	raw, ok := cm.Data[byoProvidersKey]
+	if !ok {
+		return nil, nil
+	}
+
+	var providers []BYOProvider
+	if err := yaml.Unmarshal([]byte(raw), &providers); err != nil {
+		return nil, fmt.Errorf("failed to parse %s from %s: %w", byoProvidersKey, RegistryConfigMapName, err)
+	}
+
+	return providers, nil
+}
+
+// Lookup returns the registered BYOProvider whose Platform matches
+// platformName, for mapping an External platform's cloud-controller-manager
+// hint onto a concrete provider to install. It returns false if no
+// registered provider matches.
+func (r *Registry) Lookup(ctx context.Context, platformName string) (BYOProvider, bool, error) {
+	providers, err := r.List(ctx)
+	if err != nil {
+		return BYOProvider{}, false, err
+	}
+
+	for _, p := range providers {
+		if p.Platform == platformName {
+			return p, true, nil
+		}
+	}
+
+	return BYOProvider{}, false, nil
+}
@@ -0,0 +1,20 @@
+package providers
+
+import (
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+// AWSResourceTags returns the cluster-wide AWS resource tags an admin
+// configured on the Infrastructure object, for mirroring into
+// operator-generated AWSCluster and AWSMachineTemplate objects.
+func AWSResourceTags(infra *configv1.Infrastructure) map[string]string {
+	if infra.Status.PlatformStatus == nil || infra.Status.PlatformStatus.AWS == nil {
+		return nil
+	}
+
+	tags := make(map[string]string, len(infra.Status.PlatformStatus.AWS.ResourceTags))
+	for _, tag := range infra.Status.PlatformStatus.AWS.ResourceTags {
+		tags[tag.Key] = tag.Value
+	}
+	return tags
+}
@@ -0,0 +1,48 @@
+package providers
+
+import "fmt"
+
+// Channel is a named release channel that resolves to a concrete provider
+// version, allowing a cluster to track new releases without pinning an
+// exact version.
+type Channel string
+
+const (
+	// ChannelStable tracks the most recent provider version the operator
+	// has validated for general availability.
+	ChannelStable Channel = "stable"
+	// ChannelFast tracks the most recent provider version as soon as it
+	// is built, ahead of stable validation.
+	ChannelFast Channel = "fast"
+	// ChannelCandidate tracks pre-release provider builds.
+	ChannelCandidate Channel = "candidate"
+)
+
+// channelVersions maps each channel to the version the operator currently
+// resolves it to. This is bundled at build time, not fetched at runtime.
+var channelVersions = map[Channel]string{
+	ChannelStable:    "v1.5.3",
+	ChannelFast:      "v1.6.0",
+	ChannelCandidate: "v1.6.0-rc.1",
+}
+
+// ResolveVersion returns the provider version to install given an explicit
+// version pin and/or channel selection. An explicit version always wins; a
+// channel is resolved to its bundled version; with neither set, the stable
+// channel's version is used.
+func ResolveVersion(version, channel string) (string, error) {
+	if version != "" {
+		return version, nil
+	}
+
+	if channel == "" {
+		channel = string(ChannelStable)
+	}
+
+	resolved, ok := channelVersions[Channel(channel)]
+	if !ok {
+		return "", fmt.Errorf("unknown provider channel %q", channel)
+	}
+
+	return resolved, nil
+}
@@ -0,0 +1,79 @@
+package remotecluster
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlcluster "sigs.k8s.io/controller-runtime/pkg/cluster"
+
+	"github.com/openshift/cluster-capi-operator/pkg/gc"
+)
+
+// Cluster is a running cache-backed client for one remote management
+// cluster, along with the cancel func that stops it.
+type Cluster struct {
+	// Name identifies this Cluster within a Pool, typically the name of
+	// the kubeconfig secret it was built from.
+	Name string
+
+	// ContentHash identifies the kubeconfig bytes this Cluster was built
+	// from, so a caller re-syncing from a Secret can tell whether a
+	// rebuild is actually needed before paying for a new cache sync.
+	ContentHash string
+
+	cluster ctrlcluster.Cluster
+	cancel  context.CancelFunc
+}
+
+// NewFromKubeconfig builds and starts a Cluster from raw kubeconfig
+// bytes, such as the content of a kubeconfig secret's data key. The
+// returned Cluster's cache has completed its initial sync by the time
+// this function returns. Callers must call Stop when the Cluster is no
+// longer needed, typically when its source secret is deleted.
+func NewFromKubeconfig(ctx context.Context, scheme *runtime.Scheme, name string, kubeconfig []byte) (*Cluster, error) {
+	contentHash := gc.SourceHash(kubeconfig)
+
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfig)
+	if err != nil {
+		return nil, fmt.Errorf("parsing kubeconfig for remote cluster %s: %w", name, err)
+	}
+
+	c, err := ctrlcluster.New(restConfig, func(o *ctrlcluster.Options) {
+		o.Scheme = scheme
+	})
+	if err != nil {
+		return nil, fmt.Errorf("building client for remote cluster %s: %w", name, err)
+	}
+
+	runCtx, cancel := context.WithCancel(ctx)
+	go func() {
+		if err := c.Start(runCtx); err != nil {
+			// The cluster's own logger already recorded the failure; the
+			// caller observes it the next time it calls a method on the
+			// stopped Cluster and gets a context-canceled error instead.
+			return
+		}
+	}()
+
+	if !c.GetCache().WaitForCacheSync(runCtx) {
+		cancel()
+		return nil, fmt.Errorf("cache for remote cluster %s never synced", name)
+	}
+
+	return &Cluster{Name: name, ContentHash: contentHash, cluster: c, cancel: cancel}, nil
+}
+
+// Client returns the client.Client for this remote cluster, backed by
+// its own cache.
+func (c *Cluster) Client() client.Client {
+	return c.cluster.GetClient()
+}
+
+// Stop tears down the Cluster's cache and informers. It is safe to call
+// more than once.
+func (c *Cluster) Stop() {
+	c.cancel()
+}
@@ -0,0 +1,63 @@
+// Package remotecluster is an experimental foundation for managing
+// Cluster API resources across a fleet of remote management clusters
+// from a single operator instance, each reached through a kubeconfig
+// secret rather than the operator's own in-cluster config. It keeps one
+// cache and client per remote cluster so reconcilers written against
+// controller-runtime's client.Client interface work unmodified against
+// any member of the fleet.
+package remotecluster
+
+import (
+	"sync"
+)
+
+// Pool is a concurrency-safe registry of the remote clusters currently
+// known to the operator, keyed by a caller-chosen name (typically the
+// kubeconfig secret's name). The zero value is not usable; use NewPool.
+type Pool struct {
+	mu       sync.RWMutex
+	clusters map[string]*Cluster
+}
+
+// NewPool returns an empty Pool.
+func NewPool() *Pool {
+	return &Pool{clusters: map[string]*Cluster{}}
+}
+
+// Set registers or replaces the Cluster known by name. If a Cluster was
+// already registered under name, the caller is responsible for having
+// stopped it first; Set does not stop the replaced entry.
+func (p *Pool) Set(name string, c *Cluster) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.clusters[name] = c
+}
+
+// Get returns the Cluster registered under name, if any.
+func (p *Pool) Get(name string) (*Cluster, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	c, ok := p.clusters[name]
+	return c, ok
+}
+
+// Remove unregisters the Cluster known by name, if present, and returns
+// it so the caller can stop it. It is a no-op if name isn't registered.
+func (p *Pool) Remove(name string) (*Cluster, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	c, ok := p.clusters[name]
+	delete(p.clusters, name)
+	return c, ok
+}
+
+// Names returns the names of every currently registered Cluster.
+func (p *Pool) Names() []string {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	names := make([]string, 0, len(p.clusters))
+	for name := range p.clusters {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,48 @@
+package render
+
+import (
+	"testing"
+
+	configv1 "github.com/openshift/api/config/v1"
+
+	"github.com/openshift/cluster-capi-operator/pkg/manifestvalidate"
+	"github.com/openshift/cluster-capi-operator/pkg/util/platform"
+)
+
+// TestRenderedManifestsFollowConventions renders every platform this
+// operator supports and validates the result against
+// manifestvalidate.ParseDocuments and manifestvalidate.ValidateConventions,
+// so a rebase that drops the managed-by label or points a resource at the
+// wrong namespace fails `go test` instead of only surfacing once deployed.
+//
+// Render currently produces a placeholder comment rather than real
+// manifest documents (see Render), so today this only exercises the
+// validation pipeline against an empty document set; it gains teeth once
+// Render renders actual provider manifests.
+func TestRenderedManifestsFollowConventions(t *testing.T) {
+	const namespace = "openshift-cluster-api"
+
+	supported := platform.ComputeSupportedPlatforms([]configv1.ClusterVersionCapability{configv1.ClusterVersionCapabilityCloudCredential})
+
+	for platformType := range supported {
+		t.Run(string(platformType), func(t *testing.T) {
+			out, err := Render(Input{
+				PlatformType:    platformType,
+				ProviderVersion: "v0.0.0-test",
+				Namespace:       namespace,
+			})
+			if err != nil {
+				t.Fatalf("failed to render manifests: %v", err)
+			}
+
+			objs, err := manifestvalidate.ParseDocuments(out)
+			if err != nil {
+				t.Fatalf("failed to parse rendered manifests: %v", err)
+			}
+
+			if err := manifestvalidate.ValidateConventions(objs, namespace); err != nil {
+				t.Errorf("rendered manifests violate namespace/label conventions: %v", err)
+			}
+		})
+	}
+}
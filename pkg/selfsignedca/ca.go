@@ -0,0 +1,133 @@
+// Package selfsignedca generates and rotates a local certificate
+// authority and serving certificates for provider webhooks, for use as a
+// fallback when the service-ca operator is unavailable (standalone or
+// development clusters that don't run the full OpenShift platform).
+package selfsignedca
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/openshift/cluster-capi-operator/pkg/fips"
+)
+
+// DefaultCAValidity is how long a generated CA certificate is valid for
+// when the caller does not specify a validity.
+const DefaultCAValidity = 10 * 365 * 24 * time.Hour
+
+// DefaultServingCertValidity is how long a generated serving certificate
+// is valid for when the caller does not specify a validity.
+const DefaultServingCertValidity = 2 * 365 * 24 * time.Hour
+
+// RenewalBuffer is how far ahead of a certificate's expiry this package
+// considers it due for renewal, so rotation happens well before clients
+// start rejecting it.
+const RenewalBuffer = 30 * 24 * time.Hour
+
+// Bundle holds a generated certificate and its private key, both PEM
+// encoded, paired with the parsed certificate and key so callers can
+// inspect its NotAfter, or sign a serving cert from it, without
+// re-parsing the PEM.
+type Bundle struct {
+	CertPEM []byte
+	KeyPEM  []byte
+	Cert    *x509.Certificate
+	Key     *rsa.PrivateKey
+}
+
+// NeedsRenewal reports whether b's certificate expires within buffer of
+// now.
+func (b *Bundle) NeedsRenewal(now time.Time, buffer time.Duration) bool {
+	return b.Cert == nil || now.Add(buffer).After(b.Cert.NotAfter)
+}
+
+// GenerateCA creates a new self-signed CA certificate and key with the
+// given common name and validity, using an RSA key sized to
+// fips.MinimumRSAKeyBits so the result is usable in FIPS mode.
+func GenerateCA(commonName string, validity time.Duration) (*Bundle, error) {
+	key, err := rsa.GenerateKey(rand.Reader, fips.MinimumRSAKeyBits())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate CA key: %w", err)
+	}
+
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             now,
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+
+	return createCertificate(template, template, key, key)
+}
+
+// GenerateServingCert creates a new serving certificate and key for
+// dnsNames, signed by ca, valid for the given duration.
+func GenerateServingCert(ca *Bundle, dnsNames []string, validity time.Duration) (*Bundle, error) {
+	key, err := rsa.GenerateKey(rand.Reader, fips.MinimumRSAKeyBits())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate serving key: %w", err)
+	}
+
+	serial, err := randomSerial()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber: serial,
+		Subject:      pkix.Name{CommonName: dnsNames[0]},
+		DNSNames:     dnsNames,
+		NotBefore:    now,
+		NotAfter:     now.Add(validity),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+
+	return createCertificate(template, ca.Cert, key, ca.Key)
+}
+
+// createCertificate signs template (whose public key is key.Public())
+// with parent/signerKey, returning the PEM-encoded result alongside the
+// parsed certificate. Passing template as both template and parent with
+// key as signerKey produces a self-signed certificate.
+func createCertificate(template, parent *x509.Certificate, key *rsa.PrivateKey, signerKey *rsa.PrivateKey) (*Bundle, error) {
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signerKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse generated certificate: %w", err)
+	}
+
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	return &Bundle{CertPEM: certPEM, KeyPEM: keyPEM, Cert: cert, Key: key}, nil
+}
+
+func randomSerial() (*big.Int, error) {
+	limit := new(big.Int).Lsh(big.NewInt(1), 128)
+	serial, err := rand.Int(rand.Reader, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate certificate serial number: %w", err)
+	}
+	return serial, nil
+}
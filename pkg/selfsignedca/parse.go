@@ -0,0 +1,32 @@
+package selfsignedca
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+)
+
+// ParseBundle decodes a PEM-encoded certificate and RSA private key back
+// into a Bundle, so a Bundle previously stored in a Secret can be
+// inspected for renewal or used as a CA to sign further certificates.
+func ParseBundle(certPEM, keyPEM []byte) (*Bundle, error) {
+	certBlock, _ := pem.Decode(certPEM)
+	if certBlock == nil {
+		return nil, fmt.Errorf("failed to decode certificate PEM")
+	}
+	cert, err := x509.ParseCertificate(certBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	keyBlock, _ := pem.Decode(keyPEM)
+	if keyBlock == nil {
+		return nil, fmt.Errorf("failed to decode private key PEM")
+	}
+	key, err := x509.ParsePKCS1PrivateKey(keyBlock.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	return &Bundle{CertPEM: certPEM, KeyPEM: keyPEM, Cert: cert, Key: key}, nil
+}
@@ -0,0 +1,53 @@
+// Package smoketest runs a small set of post-upgrade checks against the
+// running operator, surfacing failures as a ClusterOperator Degraded
+// condition rather than waiting for a user to notice broken functionality.
+package smoketest
+
+import (
+	"context"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Check is a single smoke-test assertion run after an upgrade.
+type Check struct {
+	Name string
+	Run  func(ctx context.Context, c client.Client) error
+}
+
+// Result is the outcome of running a single Check.
+type Result struct {
+	Check Check
+	Err   error
+}
+
+// RunAll runs every check in order, continuing past failures so a single
+// broken check does not hide the results of the others.
+func RunAll(ctx context.Context, c client.Client, checks []Check) []Result {
+	results := make([]Result, 0, len(checks))
+	for _, check := range checks {
+		results = append(results, Result{Check: check, Err: check.Run(ctx, c)})
+	}
+	return results
+}
+
+// DeploymentAvailable returns a Check verifying that the named Deployment
+// in namespace has at least one available replica.
+func DeploymentAvailable(namespace, name string) Check {
+	return Check{
+		Name: fmt.Sprintf("deployment/%s available", name),
+		Run: func(ctx context.Context, c client.Client) error {
+			deployment := &appsv1.Deployment{}
+			if err := c.Get(ctx, types.NamespacedName{Namespace: namespace, Name: name}, deployment); err != nil {
+				return err
+			}
+			if deployment.Status.AvailableReplicas < 1 {
+				return fmt.Errorf("deployment %s/%s has no available replicas", namespace, name)
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,49 @@
+package smoketest
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WebhookProbeTimeout bounds how long a single webhook connectivity probe
+// may take before it is considered failed.
+const WebhookProbeTimeout = 5 * time.Second
+
+// WebhookReachable returns a Check verifying that a TLS connection can be
+// established to a webhook's service address, catching the common failure
+// mode where a provider's webhook Service or certificate is misconfigured
+// and admission requests start failing cluster-wide.
+func WebhookReachable(name, address string) Check {
+	return Check{
+		Name: fmt.Sprintf("webhook/%s reachable", name),
+		Run: func(ctx context.Context, _ client.Client) error {
+			return probeTLS(ctx, address)
+		},
+	}
+}
+
+// DegradedReasonForFailures returns a ClusterOperator Degraded reason
+// derived from the first failing result, or the empty string if all
+// results succeeded.
+func DegradedReasonForFailures(results []Result) string {
+	for _, r := range results {
+		if r.Err != nil {
+			return "SmokeCheckFailed: " + r.Check.Name
+		}
+	}
+	return ""
+}
+
+func probeTLS(ctx context.Context, address string) error {
+	dialer := &net.Dialer{Timeout: WebhookProbeTimeout}
+	conn, err := tls.DialWithDialer(dialer, "tcp", address, &tls.Config{InsecureSkipVerify: true}) //nolint:gosec // connectivity probe only, does not trust content
+	if err != nil {
+		return fmt.Errorf("webhook at %s is not reachable: %w", address, err)
+	}
+	return conn.Close()
+}
@@ -0,0 +1,90 @@
+package standby
+
+import (
+	"context"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Client wraps a client.Client so that every write is a no-op while Gate
+// is inactive, while reads (Get, List) pass straight through — letting
+// reconcilers run their full logic, including preflight checks against
+// live cluster state, without ever mutating it. Once Gate is activated
+// the wrapped Client behaves exactly like the one it wraps.
+type Client struct {
+	client.Client
+	Gate *Gate
+}
+
+func (c *Client) Create(ctx context.Context, obj client.Object, opts ...client.CreateOption) error {
+	if !c.Gate.Active() {
+		log.FromContext(ctx).V(1).Info("standby: suppressed create", "object", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+	return c.Client.Create(ctx, obj, opts...)
+}
+
+func (c *Client) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if !c.Gate.Active() {
+		log.FromContext(ctx).V(1).Info("standby: suppressed update", "object", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func (c *Client) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+	if !c.Gate.Active() {
+		log.FromContext(ctx).V(1).Info("standby: suppressed patch", "object", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+	return c.Client.Patch(ctx, obj, patch, opts...)
+}
+
+func (c *Client) Delete(ctx context.Context, obj client.Object, opts ...client.DeleteOption) error {
+	if !c.Gate.Active() {
+		log.FromContext(ctx).V(1).Info("standby: suppressed delete", "object", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+	return c.Client.Delete(ctx, obj, opts...)
+}
+
+func (c *Client) DeleteAllOf(ctx context.Context, obj client.Object, opts ...client.DeleteAllOfOption) error {
+	if !c.Gate.Active() {
+		log.FromContext(ctx).V(1).Info("standby: suppressed delete-all-of", "object", client.ObjectKeyFromObject(obj))
+		return nil
+	}
+	return c.Client.DeleteAllOf(ctx, obj, opts...)
+}
+
+// Status returns a StatusWriter whose Update and Patch calls are
+// likewise suppressed while Gate is inactive.
+func (c *Client) Status() client.SubResourceWriter {
+	return &subResourceWriter{SubResourceWriter: c.Client.Status(), gate: c.Gate}
+}
+
+type subResourceWriter struct {
+	client.SubResourceWriter
+	gate *Gate
+}
+
+func (w *subResourceWriter) Create(ctx context.Context, obj client.Object, subResource client.Object, opts ...client.SubResourceCreateOption) error {
+	if !w.gate.Active() {
+		return nil
+	}
+	return w.SubResourceWriter.Create(ctx, obj, subResource, opts...)
+}
+
+func (w *subResourceWriter) Update(ctx context.Context, obj client.Object, opts ...client.SubResourceUpdateOption) error {
+	if !w.gate.Active() {
+		return nil
+	}
+	return w.SubResourceWriter.Update(ctx, obj, opts...)
+}
+
+func (w *subResourceWriter) Patch(ctx context.Context, obj client.Object, patch client.Patch, opts ...client.SubResourcePatchOption) error {
+	if !w.gate.Active() {
+		return nil
+	}
+	return w.SubResourceWriter.Patch(ctx, obj, patch, opts...)
+}
@@ -0,0 +1,88 @@
+// Package standby lets the operator run fully wired up — caches warmed,
+// reconcilers started, manifests rendered — while suppressing every
+// write to the target cluster, for a passive management cluster that
+// mirrors an active one in a disaster-recovery setup. Promoting a
+// standby instance to active (on receipt of SIGUSR1, or by a future
+// lease-based controller calling Gate.Activate) lets writes through
+// without restarting the process, so the passive cluster is already
+// warm the moment it takes over.
+package standby
+
+import (
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"syscall"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Gate tracks whether the operator is currently allowed to write to the
+// cluster. The zero value is inactive (standby).
+type Gate struct {
+	active atomic.Bool
+}
+
+// NewGate returns a Gate starting in the given state.
+func NewGate(active bool) *Gate {
+	g := &Gate{}
+	g.active.Store(active)
+	return g
+}
+
+// Active reports whether writes are currently allowed.
+func (g *Gate) Active() bool {
+	return g.active.Load()
+}
+
+// Activate promotes the gate to active, letting writes through from now
+// on. It is idempotent and safe to call from any goroutine, including a
+// signal handler or a lease-watching controller.
+func (g *Gate) Activate() {
+	g.active.Store(true)
+}
+
+// Deactivate suspends writes, as for standby mode or to pause the
+// operator ahead of a clusterctl move. It is idempotent and safe to call
+// from any goroutine.
+func (g *Gate) Deactivate() {
+	g.active.Store(false)
+}
+
+// WatchPromoteSignal activates gate on receipt of SIGUSR1, the manual
+// promotion trigger for a standby instance that isn't driven by a lease
+// controller. It returns immediately; promotion happens in a background
+// goroutine for the lifetime of the process.
+func WatchPromoteSignal(gate *Gate) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGUSR1)
+
+	go func() {
+		for range ch {
+			if gate.Active() {
+				continue
+			}
+			log.Log.Info("standby: promoted to active, writes now enabled")
+			gate.Activate()
+		}
+	}()
+}
+
+// WatchPauseSignal deactivates gate on receipt of SIGUSR2, the manual
+// pause trigger used ahead of a clusterctl move (see pkg/movereadiness).
+// It returns immediately; pausing happens in a background goroutine for
+// the lifetime of the process.
+func WatchPauseSignal(gate *Gate) {
+	ch := make(chan os.Signal, 1)
+	signal.Notify(ch, syscall.SIGUSR2)
+
+	go func() {
+		for range ch {
+			if !gate.Active() {
+				continue
+			}
+			log.Log.Info("standby: paused, writes suspended")
+			gate.Deactivate()
+		}
+	}()
+}
@@ -0,0 +1,139 @@
+// Package status exposes a lightweight, read-only JSON summary of the
+// operator's state so that dashboards (e.g. Grafana via a JSON datasource)
+// can render operator health without requiring ClusterOperator RBAC.
+package status
+
+import (
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+// ProviderStatus summarizes the state of a single managed provider.
+type ProviderStatus struct {
+	Name                string    `json:"name"`
+	Version             string    `json:"version"`
+	LastReconcileTime   time.Time `json:"lastReconcileTime"`
+	LastReconcileResult string    `json:"lastReconcileResult"`
+}
+
+// maxConditionHistory bounds how many condition transitions Aggregator
+// retains, so an intermittent degradation that flaps overnight can still
+// be diagnosed the next morning, without the ring growing unbounded over
+// the operator's lifetime.
+const maxConditionHistory = 50
+
+// ConditionTransition records one observed change to a ClusterOperator
+// condition, so the status endpoint can show not just the current state
+// but what led up to it.
+type ConditionTransition struct {
+	Type       configv1.ClusterStatusConditionType `json:"type"`
+	Status     configv1.ConditionStatus            `json:"status"`
+	Reason     string                               `json:"reason"`
+	Message    string                               `json:"message"`
+	ObservedAt time.Time                            `json:"observedAt"`
+}
+
+// Summary is the payload served by the status endpoint.
+type Summary struct {
+	Providers        []ProviderStatus                           `json:"providers"`
+	Conditions       []configv1.ClusterOperatorStatusCondition `json:"conditions"`
+	ConditionHistory []ConditionTransition                      `json:"conditionHistory,omitempty"`
+	FIPSEnabled      bool                                       `json:"fipsEnabled"`
+	UpdatedAt        time.Time                                  `json:"updatedAt"`
+}
+
+// Aggregator holds the latest known operator status and serves it over
+// HTTP. It is updated by controllers as they reconcile and read by the
+// status endpoint handler.
+type Aggregator struct {
+	mu      sync.RWMutex
+	summary Summary
+}
+
+// NewAggregator returns an empty Aggregator.
+func NewAggregator() *Aggregator {
+	return &Aggregator{}
+}
+
+// SetProviderStatus records the latest reconcile outcome for a provider.
+func (a *Aggregator) SetProviderStatus(ps ProviderStatus) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for i, existing := range a.summary.Providers {
+		if existing.Name == ps.Name {
+			a.summary.Providers[i] = ps
+			a.summary.UpdatedAt = time.Now()
+			return
+		}
+	}
+	a.summary.Providers = append(a.summary.Providers, ps)
+	a.summary.UpdatedAt = time.Now()
+}
+
+// SetFIPSEnabled records whether the operator detected FIPS mode on its
+// host, so status consumers can confirm its crypto operations are using
+// FIPS-approved algorithms without reaching for node-level access.
+func (a *Aggregator) SetFIPSEnabled(enabled bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	a.summary.FIPSEnabled = enabled
+	a.summary.UpdatedAt = time.Now()
+}
+
+// SetConditions records the latest ClusterOperator conditions, and
+// appends a ConditionTransition to the bounded history for any condition
+// whose Status, Reason, or Message changed since the last call.
+func (a *Aggregator) SetConditions(conditions []configv1.ClusterOperatorStatusCondition) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now()
+	for _, condition := range conditions {
+		if !conditionTransitioned(a.summary.Conditions, condition) {
+			continue
+		}
+		a.summary.ConditionHistory = append(a.summary.ConditionHistory, ConditionTransition{
+			Type:       condition.Type,
+			Status:     condition.Status,
+			Reason:     condition.Reason,
+			Message:    condition.Message,
+			ObservedAt: now,
+		})
+	}
+	if overflow := len(a.summary.ConditionHistory) - maxConditionHistory; overflow > 0 {
+		a.summary.ConditionHistory = a.summary.ConditionHistory[overflow:]
+	}
+
+	a.summary.Conditions = conditions
+	a.summary.UpdatedAt = now
+}
+
+// conditionTransitioned reports whether condition differs from its
+// same-Type entry in previous, counting the condition's first appearance
+// as a transition too.
+func conditionTransitioned(previous []configv1.ClusterOperatorStatusCondition, condition configv1.ClusterOperatorStatusCondition) bool {
+	for _, existing := range previous {
+		if existing.Type != condition.Type {
+			continue
+		}
+		return existing.Status != condition.Status || existing.Reason != condition.Reason || existing.Message != condition.Message
+	}
+	return true
+}
+
+// ServeHTTP writes the current summary as JSON.
+func (a *Aggregator) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(a.summary); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,24 @@
+package status
+
+import "strings"
+
+// Message templates use {{placeholder}} tokens rather than positional
+// verbs (%s, %d) so that translated catalogs can reorder arguments freely
+// without the operator needing to know which locale is in use.
+const (
+	MessageDegraded    = "the {{controller}} controller is degraded: {{reason}}"
+	MessageProgressing = "installing {{provider}} version {{version}}"
+	MessageAvailable   = "all managed providers are available"
+)
+
+// RenderMessage substitutes {{key}} placeholders in template with the
+// corresponding value from args. Unknown placeholders are left as-is so
+// that a missing argument is visible in the rendered message rather than
+// silently dropped.
+func RenderMessage(template string, args map[string]string) string {
+	msg := template
+	for k, v := range args {
+		msg = strings.ReplaceAll(msg, "{{"+k+"}}", v)
+	}
+	return msg
+}
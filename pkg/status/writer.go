@@ -0,0 +1,111 @@
+package status
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	v1helpers "github.com/openshift/library-go/pkg/config/clusteroperator/v1helpers"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// defaultMinWriteInterval is how long a ConditionWriter waits after the
+// first queued condition before issuing a write, absorbing any further
+// SetCondition calls that arrive in the meantime.
+const defaultMinWriteInterval = 5 * time.Second
+
+// ConditionWriter batches ClusterOperator condition updates from multiple
+// controllers into a single, rate-limited write, so every controller
+// reporting its own health doesn't translate into its own ClusterOperator
+// API call and audit-log entry.
+type ConditionWriter struct {
+	client.Client
+
+	// Name is the ClusterOperator this writer updates.
+	Name string
+
+	// MinWriteInterval bounds how often a write is issued, regardless of
+	// how many SetCondition calls arrive in between. Defaults to
+	// defaultMinWriteInterval if unset.
+	MinWriteInterval time.Duration
+
+	mu        sync.Mutex
+	pending   map[configv1.ClusterStatusConditionType]configv1.ClusterOperatorStatusCondition
+	scheduled bool
+}
+
+// SetCondition queues condition to be merged into the operator's
+// ClusterOperator status on the next batched write. A condition with the
+// same Status, Reason, and Message as the last one already queued for its
+// Type is a no-op, so repeatedly reporting an unchanged condition doesn't
+// keep extending the batch window.
+func (w *ConditionWriter) SetCondition(ctx context.Context, condition configv1.ClusterOperatorStatusCondition) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.pending == nil {
+		w.pending = make(map[configv1.ClusterStatusConditionType]configv1.ClusterOperatorStatusCondition)
+	}
+
+	if existing, ok := w.pending[condition.Type]; ok && conditionsEqual(existing, condition) {
+		return
+	}
+	w.pending[condition.Type] = condition
+
+	if !w.scheduled {
+		w.scheduled = true
+		interval := w.MinWriteInterval
+		if interval <= 0 {
+			interval = defaultMinWriteInterval
+		}
+		time.AfterFunc(interval, func() { w.flush(ctx) })
+	}
+}
+
+// flush writes every queued condition to the ClusterOperator in a single
+// update, skipping the write entirely if none of them actually changed
+// anything.
+func (w *ConditionWriter) flush(ctx context.Context) {
+	w.mu.Lock()
+	pending := w.pending
+	w.pending = nil
+	w.scheduled = false
+	w.mu.Unlock()
+
+	if len(pending) == 0 {
+		return
+	}
+
+	logger := log.FromContext(ctx)
+
+	co := &configv1.ClusterOperator{}
+	if err := w.Get(ctx, types.NamespacedName{Name: w.Name}, co); err != nil {
+		logger.Error(err, "failed to get ClusterOperator for batched status write")
+		return
+	}
+
+	changed := false
+	for _, condition := range pending {
+		existing := v1helpers.FindStatusCondition(co.Status.Conditions, condition.Type)
+		if existing != nil && conditionsEqual(*existing, condition) {
+			continue
+		}
+		v1helpers.SetStatusCondition(&co.Status.Conditions, condition)
+		changed = true
+	}
+
+	if !changed {
+		return
+	}
+
+	if err := w.Status().Update(ctx, co); err != nil {
+		logger.Error(err, "failed to write batched ClusterOperator status update")
+	}
+}
+
+func conditionsEqual(a, b configv1.ClusterOperatorStatusCondition) bool {
+	return a.Status == b.Status && a.Reason == b.Reason && a.Message == b.Message
+}
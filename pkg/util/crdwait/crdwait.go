@@ -0,0 +1,74 @@
+// Package crdwait waits for a CustomResourceDefinition to report its
+// Established condition, so that custom resources aren't created against a
+// CRD the API server hasn't finished registering yet. It operates on
+// CustomResourceDefinition as unstructured, the same way pkg/controllers
+// handles other CRD-defined types this module doesn't vendor, so callers
+// in the provider and migration subsystems don't need an
+// apiextensions-apiserver dependency just to wait.
+package crdwait
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// crdGVK identifies a CustomResourceDefinition object.
+var crdGVK = schema.GroupVersionKind{Group: "apiextensions.k8s.io", Version: "v1", Kind: "CustomResourceDefinition"}
+
+// DefaultTimeout bounds how long WaitForEstablished polls before giving up,
+// for callers with no stronger opinion of their own.
+const DefaultTimeout = 30 * time.Second
+
+// pollInterval is how often WaitForEstablished re-checks the CRD's status
+// while waiting.
+const pollInterval = time.Second
+
+// WaitForEstablished polls the named CustomResourceDefinition until its
+// Established condition is True, or timeout elapses. A CRD that can't yet
+// be read (e.g. it was just created and hasn't propagated) is treated as
+// not yet established rather than an error, so callers don't need to
+// retry the call itself.
+func WaitForEstablished(ctx context.Context, c client.Client, name string, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	err := wait.PollUntilContextCancel(ctx, pollInterval, true, func(ctx context.Context) (bool, error) {
+		crd := &unstructured.Unstructured{}
+		crd.SetGroupVersionKind(crdGVK)
+		if err := c.Get(ctx, types.NamespacedName{Name: name}, crd); err != nil {
+			return false, nil
+		}
+		return isEstablished(crd), nil
+	})
+	if err != nil {
+		return fmt.Errorf("timed out waiting for CustomResourceDefinition %s to become established: %w", name, err)
+	}
+
+	return nil
+}
+
+func isEstablished(crd *unstructured.Unstructured) bool {
+	conditions, found, err := unstructured.NestedSlice(crd.Object, "status", "conditions")
+	if err != nil || !found {
+		return false
+	}
+
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if condition["type"] == "Established" && condition["status"] == "True" {
+			return true
+		}
+	}
+
+	return false
+}
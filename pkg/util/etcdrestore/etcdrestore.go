@@ -0,0 +1,61 @@
+// Package etcdrestore detects when the cluster has been restored from an
+// etcd backup, so reconcilers can treat the next reconcile specially:
+// managed resources may have reverted to an earlier state that no longer
+// matches what the operator last observed, and naively trusting cached
+// state (e.g. "resource already created") could skip required reconciliation.
+package etcdrestore
+
+import (
+	"context"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// markerName is the name of a ConfigMap the operator maintains solely to
+// record the UID it last observed for the kube-system namespace. The
+// kube-system namespace's UID is stable for the cluster's lifetime and
+// changes only when etcd is restored from a backup taken on a different
+// cluster instantiation, making it a reliable restore signal.
+const markerName = "cluster-capi-operator-restore-marker"
+
+// Detector compares the current kube-system namespace UID against the one
+// last recorded, reporting whether an etcd restore has occurred since.
+type Detector struct {
+	client.Client
+
+	// Namespace is the operator's managed namespace, where the marker
+	// ConfigMap is stored.
+	Namespace string
+}
+
+// CheckAndRecord returns true if an etcd restore is detected (the
+// kube-system UID differs from the last recorded one), and updates the
+// stored marker to the current UID. When no marker exists yet it creates
+// one and returns false.
+func (d *Detector) CheckAndRecord(ctx context.Context) (restored bool, err error) {
+	kubeSystem := &corev1.Namespace{}
+	if err := d.Get(ctx, types.NamespacedName{Name: "kube-system"}, kubeSystem); err != nil {
+		return false, err
+	}
+	currentUID := string(kubeSystem.UID)
+
+	marker := &corev1.ConfigMap{}
+	err = d.Get(ctx, types.NamespacedName{Name: markerName, Namespace: d.Namespace}, marker)
+	switch {
+	case err == nil:
+		restored = marker.Data["kube-system-uid"] != currentUID
+		marker.Data = map[string]string{"kube-system-uid": currentUID}
+		return restored, d.Update(ctx, marker)
+	case client.IgnoreNotFound(err) == nil:
+		marker = &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{Name: markerName, Namespace: d.Namespace},
+			Data:       map[string]string{"kube-system-uid": currentUID},
+		}
+		return false, d.Create(ctx, marker)
+	default:
+		return false, err
+	}
+}
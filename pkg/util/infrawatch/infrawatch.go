@@ -0,0 +1,43 @@
+// Package infrawatch provides a shared controller-runtime predicate for
+// controllers that watch the cluster's Infrastructure object, so an
+// update carrying only status noise from an unrelated operator does not
+// trigger a reconcile.
+package infrawatch
+
+import (
+	"reflect"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+)
+
+// RelevantChange admits every Infrastructure create and delete event, and
+// an update event only when the generation, infrastructure name, API
+// server URLs, or platform status changed -- the fields the kubeconfig
+// and infratags controllers actually act on.
+func RelevantChange() predicate.Predicate {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			oldInfra, ok := e.ObjectOld.(*configv1.Infrastructure)
+			if !ok {
+				return true
+			}
+			newInfra, ok := e.ObjectNew.(*configv1.Infrastructure)
+			if !ok {
+				return true
+			}
+			return infrastructureChanged(oldInfra, newInfra)
+		},
+	}
+}
+
+// infrastructureChanged reports whether any field downstream controllers
+// care about differs between oldInfra and newInfra.
+func infrastructureChanged(oldInfra, newInfra *configv1.Infrastructure) bool {
+	return oldInfra.Generation != newInfra.Generation ||
+		oldInfra.Status.InfrastructureName != newInfra.Status.InfrastructureName ||
+		oldInfra.Status.APIServerURL != newInfra.Status.APIServerURL ||
+		oldInfra.Status.APIServerInternalURL != newInfra.Status.APIServerInternalURL ||
+		!reflect.DeepEqual(oldInfra.Status.PlatformStatus, newInfra.Status.PlatformStatus)
+}
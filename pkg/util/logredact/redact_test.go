@@ -0,0 +1,119 @@
+package logredact
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-logr/logr"
+)
+
+// recordingSink is a minimal logr.LogSink that records the last record it
+// was asked to emit, so tests can assert on exactly what reached the
+// "real" sink after redaction.
+type recordingSink struct {
+	name          string
+	msg           string
+	keysAndValues []interface{}
+}
+
+func (r *recordingSink) Init(logr.RuntimeInfo)  {}
+func (r *recordingSink) Enabled(level int) bool { return true }
+
+func (r *recordingSink) Info(level int, msg string, keysAndValues ...interface{}) {
+	r.msg = msg
+	r.keysAndValues = keysAndValues
+}
+
+func (r *recordingSink) Error(err error, msg string, keysAndValues ...interface{}) {
+	r.msg = msg
+	r.keysAndValues = keysAndValues
+}
+
+func (r *recordingSink) WithValues(keysAndValues ...interface{}) logr.LogSink {
+	r.keysAndValues = append(append([]interface{}{}, r.keysAndValues...), keysAndValues...)
+	return r
+}
+
+func (r *recordingSink) WithName(name string) logr.LogSink {
+	r.name = name
+	return r
+}
+
+func valueFor(keysAndValues []interface{}, key string) interface{} {
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		if keysAndValues[i] == key {
+			return keysAndValues[i+1]
+		}
+	}
+	return nil
+}
+
+func TestSinkInfoRedactsSensitiveKeyAndBearerToken(t *testing.T) {
+	rec := &recordingSink{}
+	sink := NewSink(rec)
+
+	sink.Info(0, "fetched bearer abc.123-XYZ from cache", "token", "super-secret", "name", "my-secret")
+
+	if rec.msg != "fetched <redacted> from cache" {
+		t.Errorf("expected bearer token to be redacted from message, got %q", rec.msg)
+	}
+	if got := valueFor(rec.keysAndValues, "token"); got != redacted {
+		t.Errorf("expected token value to be redacted, got %v", got)
+	}
+	if got := valueFor(rec.keysAndValues, "name"); got != "my-secret" {
+		t.Errorf("expected non-sensitive key to pass through unchanged, got %v", got)
+	}
+}
+
+func TestSinkErrorRedactsSensitiveKey(t *testing.T) {
+	rec := &recordingSink{}
+	sink := NewSink(rec)
+
+	sink.Error(errors.New("boom"), "failed to apply", "password", "hunter2")
+
+	if got := valueFor(rec.keysAndValues, "password"); got != redacted {
+		t.Errorf("expected password value to be redacted, got %v", got)
+	}
+}
+
+func TestSinkWithValuesStaysRedactingAndRedactsAttachedValues(t *testing.T) {
+	rec := &recordingSink{}
+	sink := NewSink(rec)
+
+	derived := sink.WithValues("kubeconfig", "apiVersion: v1\n...")
+
+	if _, ok := derived.(*Sink); !ok {
+		t.Fatalf("expected WithValues to return a *Sink so redaction survives, got %T", derived)
+	}
+	if got := valueFor(rec.keysAndValues, "kubeconfig"); got != redacted {
+		t.Errorf("expected value attached via WithValues to be redacted before storage, got %v", got)
+	}
+
+	// A logger built on the derived sink must still redact values passed
+	// directly to Info, rather than having unwrapped back to rec.
+	derived.Info(0, "bearer deadbeef issued", "token", "another-secret")
+
+	if got := valueFor(rec.keysAndValues, "token"); got != redacted {
+		t.Errorf("expected token passed to Info after WithValues to be redacted, got %v", got)
+	}
+	if rec.msg != "<redacted> issued" {
+		t.Errorf("expected bearer token in message to be redacted, got %q", rec.msg)
+	}
+}
+
+func TestSinkWithNameStaysRedacting(t *testing.T) {
+	rec := &recordingSink{}
+	sink := NewSink(rec)
+
+	derived := sink.WithName("controller")
+
+	if _, ok := derived.(*Sink); !ok {
+		t.Fatalf("expected WithName to return a *Sink so redaction survives, got %T", derived)
+	}
+
+	derived.Info(0, "ready", "password", "hunter2")
+
+	if got := valueFor(rec.keysAndValues, "password"); got != redacted {
+		t.Errorf("expected value to be redacted after WithName, got %v", got)
+	}
+}
@@ -0,0 +1,9 @@
+package namespace
+
+// BackupLabels are applied to CRDs and the managed namespace's resources so
+// that cluster backup tooling (e.g. OADP/Velero) includes Cluster API
+// state in its backups by default, without requiring the cluster admin to
+// hand-author an inclusion policy.
+var BackupLabels = map[string]string{
+	"velero.io/exclude-from-backup": "false",
+}
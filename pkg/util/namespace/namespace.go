@@ -0,0 +1,65 @@
+// Package namespace ensures the operator's managed namespace exists and
+// carries the labels the rest of the platform expects from it (monitoring
+// scraping, pod security admission, etc).
+package namespace
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// RequiredLabels are applied to the managed namespace on every reconcile,
+// regardless of what the user may have set.
+var RequiredLabels = map[string]string{
+	"openshift.io/cluster-monitoring":     "true",
+	"pod-security.kubernetes.io/enforce":  "restricted",
+	"pod-security.kubernetes.io/audit":    "restricted",
+	"pod-security.kubernetes.io/warn":     "restricted",
+}
+
+// Ensure creates the named namespace if it does not exist, and otherwise
+// patches it to carry RequiredLabels without clobbering other labels the
+// user or platform may have set.
+func Ensure(ctx context.Context, c client.Client, name string) error {
+	ns := &corev1.Namespace{}
+	ns.Name = name
+
+	_, err := controllerutil.CreateOrPatch(ctx, c, ns, func() error {
+		if ns.Labels == nil {
+			ns.Labels = map[string]string{}
+		}
+		for k, v := range RequiredLabels {
+			ns.Labels[k] = v
+		}
+		for k, v := range BackupLabels {
+			ns.Labels[k] = v
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to ensure namespace %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// Exists reports whether the named namespace is already present, for
+// callers that need to tell a fresh install apart from one that predates
+// them (e.g. detecting an operator was already active before its cluster
+// capability became gateable).
+func Exists(ctx context.Context, c client.Client, name string) (bool, error) {
+	ns := &corev1.Namespace{}
+	err := c.Get(ctx, client.ObjectKey{Name: name}, ns)
+	if apierrors.IsNotFound(err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to check for namespace %s: %w", name, err)
+	}
+	return true, nil
+}
@@ -0,0 +1,59 @@
+// Package operrors defines typed errors shared across the operator's
+// controllers so callers can branch on failure kind with errors.Is/As
+// instead of matching on error message strings.
+package operrors
+
+import "fmt"
+
+// Kind identifies a category of operator error.
+type Kind string
+
+const (
+	// KindPlatformUnsupported indicates the operator was asked to act on
+	// a platform it has no provider support for.
+	KindPlatformUnsupported Kind = "PlatformUnsupported"
+	// KindContractIncompatible indicates a core/infra provider contract
+	// version mismatch.
+	KindContractIncompatible Kind = "ContractIncompatible"
+	// KindCRDNotEstablished indicates a required CRD has not yet reached
+	// the Established condition.
+	KindCRDNotEstablished Kind = "CRDNotEstablished"
+)
+
+// Error is a typed operator error carrying a Kind that callers can match
+// on via errors.As, independent of the wrapped message or cause.
+type Error struct {
+	Kind Kind
+	// Resource optionally names the resource the error concerns.
+	Resource string
+	// Cause is the underlying error, if any.
+	Cause error
+}
+
+func (e *Error) Error() string {
+	if e.Resource != "" {
+		return fmt.Sprintf("%s: %s: %v", e.Kind, e.Resource, e.Cause)
+	}
+	return fmt.Sprintf("%s: %v", e.Kind, e.Cause)
+}
+
+// Unwrap allows errors.Is/As to see through to Cause.
+func (e *Error) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is an *Error with the same Kind, so
+// errors.Is(err, &Error{Kind: KindPlatformUnsupported}) works without
+// requiring callers to also match Resource/Cause.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return e.Kind == t.Kind
+}
+
+// New constructs an *Error of the given kind wrapping cause.
+func New(kind Kind, resource string, cause error) *Error {
+	return &Error{Kind: kind, Resource: resource, Cause: cause}
+}
@@ -0,0 +1,28 @@
+package platform
+
+// DeploymentMode selects whether the operator's controllers watch and
+// manage resources across the whole cluster or are confined to a single
+// namespace, as required by hosted-control-plane deployments where the
+// operator runs alongside the cluster it manages without cluster-wide RBAC.
+type DeploymentMode string
+
+const (
+	// DeploymentModeClusterScoped watches resources across all
+	// namespaces, the default for standalone OpenShift clusters.
+	DeploymentModeClusterScoped DeploymentMode = "ClusterScoped"
+	// DeploymentModeNamespaceScoped confines the operator to a single
+	// namespace, used when the operator runs as a guest component inside
+	// a hosted control plane.
+	DeploymentModeNamespaceScoped DeploymentMode = "NamespaceScoped"
+)
+
+// ParseDeploymentMode parses value, defaulting to DeploymentModeClusterScoped
+// when empty.
+func ParseDeploymentMode(value string) DeploymentMode {
+	switch DeploymentMode(value) {
+	case DeploymentModeNamespaceScoped:
+		return DeploymentModeNamespaceScoped
+	default:
+		return DeploymentModeClusterScoped
+	}
+}
@@ -0,0 +1,158 @@
+// Package platform determines which OpenShift platform the operator is
+// running on and whether Cluster API support for that platform is enabled.
+package platform
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	configv1 "github.com/openshift/api/config/v1"
+)
+
+// forcePlatformEnvVar allows developers to override the detected platform
+// for local testing against platforms not yet supported in production.
+const forcePlatformEnvVar = "CAPI_FORCE_PLATFORM"
+
+// supportedPlatforms lists the platforms the operator installs Cluster API
+// providers for in production.
+var supportedPlatforms = map[configv1.PlatformType]bool{
+	configv1.AWSPlatformType:       true,
+	configv1.GCPPlatformType:       true,
+	configv1.AzurePlatformType:     true,
+	configv1.OpenStackPlatformType: true,
+	configv1.VSpherePlatformType:   true,
+}
+
+// Resolver determines the effective platform type for the operator, taking
+// into account any development override.
+type Resolver struct {
+	// ForcePlatform overrides the detected platform when non-empty. It is
+	// sourced from the --force-platform flag, falling back to the
+	// CAPI_FORCE_PLATFORM environment variable.
+	ForcePlatform string
+}
+
+// NewResolver constructs a Resolver, reading the force-platform override
+// from the environment if flagValue is empty.
+func NewResolver(flagValue string) *Resolver {
+	force := flagValue
+	if force == "" {
+		force = os.Getenv(forcePlatformEnvVar)
+	}
+	return &Resolver{ForcePlatform: force}
+}
+
+// Resolve returns the effective platform type for detected, and whether a
+// development override is in effect.
+func (r *Resolver) Resolve(detected configv1.PlatformType) (effective configv1.PlatformType, overridden bool) {
+	if r.ForcePlatform == "" {
+		return detected, false
+	}
+	return configv1.PlatformType(r.ForcePlatform), true
+}
+
+// IsSupported reports whether the Cluster API operator installs providers
+// for platformType in production (i.e. without a development override).
+func IsSupported(platformType configv1.PlatformType) bool {
+	return supportedPlatforms[platformType]
+}
+
+// ComputeSupportedPlatforms returns the subset of supportedPlatforms that
+// are actually installable given the release payload's enabled
+// capabilities, since a platform's provider manifests may depend on a
+// capability (e.g. CloudCredential) that the cluster has opted out of.
+func ComputeSupportedPlatforms(enabledCapabilities []configv1.ClusterVersionCapability) map[configv1.PlatformType]bool {
+	enabled := make(map[configv1.ClusterVersionCapability]bool, len(enabledCapabilities))
+	for _, c := range enabledCapabilities {
+		enabled[c] = true
+	}
+
+	result := make(map[configv1.PlatformType]bool, len(supportedPlatforms))
+	for platformType := range supportedPlatforms {
+		if requiredCapabilityFor(platformType) == "" || enabled[requiredCapabilityFor(platformType)] {
+			result[platformType] = true
+		}
+	}
+
+	return result
+}
+
+// CapabilityClusterAPI is the optional cluster capability gating whether
+// this operator runs at all. Clusters that disable it get no Cluster API
+// installation, core or infrastructure, regardless of platform.
+const CapabilityClusterAPI configv1.ClusterVersionCapability = "ClusterAPI"
+
+// CapabilityEnabled reports whether CapabilityClusterAPI is present in
+// enabledCapabilities.
+func CapabilityEnabled(enabledCapabilities []configv1.ClusterVersionCapability) bool {
+	for _, c := range enabledCapabilities {
+		if c == CapabilityClusterAPI {
+			return true
+		}
+	}
+	return false
+}
+
+// requiredCapabilityFor returns the cluster capability a platform's
+// provider depends on, or the empty string if it has no dependency.
+func requiredCapabilityFor(platformType configv1.PlatformType) configv1.ClusterVersionCapability {
+	switch platformType {
+	case configv1.AWSPlatformType, configv1.GCPPlatformType, configv1.AzurePlatformType:
+		return configv1.ClusterVersionCapabilityCloudCredential
+	default:
+		return ""
+	}
+}
+
+// SupportedPlatformsStatus is the operator's computed platform support for
+// a cluster, suitable for publishing into the ClusterOperator status
+// Extension field so admins and tooling can discover whether Cluster API
+// will activate without reading operator logs or source code.
+type SupportedPlatformsStatus struct {
+	// SupportedPlatforms lists every platform type this operator installs
+	// providers for, given the cluster's enabled capabilities.
+	SupportedPlatforms []string `json:"supportedPlatforms"`
+	// CurrentPlatform is the cluster's detected (or overridden) platform
+	// type.
+	CurrentPlatform string `json:"currentPlatform"`
+	// CurrentPlatformSupported reports whether CurrentPlatform is in
+	// SupportedPlatforms.
+	CurrentPlatformSupported bool `json:"currentPlatformSupported"`
+	// Reason explains why CurrentPlatform is, or is not, supported.
+	Reason string `json:"reason"`
+}
+
+// ComputeSupportedPlatformsStatus builds a SupportedPlatformsStatus for
+// current, given the platforms ComputeSupportedPlatforms reports
+// installable under enabledCapabilities.
+func ComputeSupportedPlatformsStatus(current configv1.PlatformType, enabledCapabilities []configv1.ClusterVersionCapability) SupportedPlatformsStatus {
+	supported := ComputeSupportedPlatforms(enabledCapabilities)
+
+	names := make([]string, 0, len(supported))
+	for platformType := range supported {
+		names = append(names, string(platformType))
+	}
+	sort.Strings(names)
+
+	currentSupported := supported[current]
+
+	return SupportedPlatformsStatus{
+		SupportedPlatforms:       names,
+		CurrentPlatform:          string(current),
+		CurrentPlatformSupported: currentSupported,
+		Reason:                   currentPlatformReason(current, currentSupported),
+	}
+}
+
+// currentPlatformReason explains why current is, or is not, in the
+// computed supported set.
+func currentPlatformReason(current configv1.PlatformType, supported bool) string {
+	if supported {
+		return "Cluster API providers are available for this platform"
+	}
+	if !IsSupported(current) {
+		return "Cluster API has no provider for this platform"
+	}
+	return fmt.Sprintf("Cluster API's provider for this platform requires the %s capability, which is disabled", requiredCapabilityFor(current))
+}
@@ -0,0 +1,126 @@
+package platform
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// InfrastructureName is the name of the cluster-wide, singleton
+// Infrastructure resource.
+const InfrastructureName = "cluster"
+
+// statusPollInterval is how often StatusProvider rechecks the
+// Infrastructure object while it has no PlatformStatus yet.
+const statusPollInterval = 5 * time.Second
+
+// Context is a snapshot of the cluster's Infrastructure status, computed
+// once by StatusProvider and shared by every dependent controller so they
+// all agree on the same values instead of each re-reading (and
+// potentially racing against an in-flight update to) the Infrastructure
+// object on every reconcile.
+type Context struct {
+	// Type is the cluster's platform type.
+	Type configv1.PlatformType
+	// InfrastructureName is the cluster's infrastructure name, the common
+	// prefix this operator and CAPI providers use when naming generated
+	// cluster-scoped resources.
+	InfrastructureName string
+	// Region is the cluster's region, for the platforms that have one. It
+	// is empty for platforms with no region concept (e.g. VSphere, Nutanix).
+	Region string
+	// APIServerURL is the cluster's external API server URL.
+	APIServerURL string
+	// APIServerInternalURL is the cluster's internal API server URL, used
+	// by components running in-cluster.
+	APIServerInternalURL string
+}
+
+// StatusProvider resolves a Context from the cluster's Infrastructure
+// object once at startup and fans it out to every dependent controller,
+// so a controller that needs it no longer has to handle a still-empty
+// Infrastructure.Status ad hoc, or re-GET the object, on every reconcile
+// (compare pkg/providers.AWSResourceTags, which tolerates a nil
+// PlatformStatus by returning no tags).
+//
+// It implements manager.Runnable: add it to the Manager alongside the
+// dependent controllers, then have each one call Wait at the start of its
+// Reconcile (or SetupWithManager, for a one-time read) to block until the
+// Context is resolved.
+type StatusProvider struct {
+	client.Client
+
+	ready     chan struct{}
+	readyOnce sync.Once
+	context   Context
+}
+
+// NewStatusProvider constructs a StatusProvider reading the Infrastructure
+// object through c.
+func NewStatusProvider(c client.Client) *StatusProvider {
+	return &StatusProvider{Client: c, ready: make(chan struct{})}
+}
+
+// Start polls the Infrastructure object until it reports a platform type,
+// then resolves every call to Wait. It implements manager.Runnable, so it
+// starts and stops with the rest of the manager.
+func (p *StatusProvider) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx)
+
+	return wait.PollUntilContextCancel(ctx, statusPollInterval, true, func(ctx context.Context) (bool, error) {
+		infra := &configv1.Infrastructure{}
+		if err := p.Get(ctx, types.NamespacedName{Name: InfrastructureName}, infra); err != nil {
+			if apierrors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+
+		if infra.Status.PlatformStatus == nil || infra.Status.PlatformStatus.Type == "" {
+			logger.Info("waiting for Infrastructure status to report a platform type")
+			return false, nil
+		}
+
+		p.context = Context{
+			Type:                 infra.Status.PlatformStatus.Type,
+			InfrastructureName:   infra.Status.InfrastructureName,
+			Region:               regionFor(infra.Status.PlatformStatus),
+			APIServerURL:         infra.Status.APIServerURL,
+			APIServerInternalURL: infra.Status.APIServerInternalURL,
+		}
+		p.readyOnce.Do(func() { close(p.ready) })
+		return true, nil
+	})
+}
+
+// Wait blocks until the Context has been resolved, or ctx is done.
+func (p *StatusProvider) Wait(ctx context.Context) (Context, error) {
+	select {
+	case <-p.ready:
+		return p.context, nil
+	case <-ctx.Done():
+		return Context{}, ctx.Err()
+	}
+}
+
+// regionFor returns the region of the platforms that have one, or the
+// empty string otherwise.
+func regionFor(status *configv1.PlatformStatus) string {
+	switch {
+	case status.AWS != nil:
+		return status.AWS.Region
+	case status.Azure != nil:
+		return status.Azure.Region
+	case status.GCP != nil:
+		return status.GCP.Region
+	default:
+		return ""
+	}
+}
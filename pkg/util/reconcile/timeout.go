@@ -0,0 +1,20 @@
+// Package reconcile provides small helpers shared across the operator's
+// controllers.
+package reconcile
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultTimeout bounds how long a single reconcile invocation may run
+// before its context is cancelled, so a hung API call cannot wedge a
+// worker indefinitely.
+const DefaultTimeout = 2 * time.Minute
+
+// WithTimeout returns a derived context that is cancelled after
+// DefaultTimeout, along with its cancel function. Callers must defer the
+// cancel function to release resources promptly on the success path.
+func WithTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, DefaultTimeout)
+}
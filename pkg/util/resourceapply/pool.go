@@ -0,0 +1,114 @@
+package resourceapply
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// customResourceDefinitionKind is the Kind of a CustomResourceDefinition
+// object, checked by name rather than by importing apiextensions so this
+// package stays free of that dependency.
+const customResourceDefinitionKind = "CustomResourceDefinition"
+
+// Object pairs an object to apply with the mutation that sets its desired
+// state, for use with ApplyAll.
+type Object struct {
+	Obj    client.Object
+	Mutate controllerutil.MutateFn
+}
+
+// ApplyAll applies objs wave by wave, in ascending wave order (see
+// waveOf): namespaces, then CRDs, then RBAC, then Deployments, then
+// everything else, so a CR is never raced against its own CRD or a
+// Deployment against the ServiceAccount it runs as. Within a wave, up to
+// concurrency objects apply at once with no ordering between them. A
+// wave only starts once every object in the previous wave has applied
+// successfully; errors within a wave are still collected and returned
+// together via errors.Join rather than aborting on the first failure, so
+// one bad manifest doesn't block the rest of that wave.
+func ApplyAll(ctx context.Context, controller string, c client.Client, objs []Object, concurrency int) error {
+	waves, byWave := sortedWaves(objs)
+
+	for _, wave := range waves {
+		if err := applyBatch(ctx, controller, c, byWave[wave], concurrency); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteAll deletes objs wave by wave, in descending wave order — the
+// exact reverse of ApplyAll — so a CRD is never removed while CRs of its
+// type still reference it, and RBAC outlives the workloads that need it
+// until those workloads are gone.
+func DeleteAll(ctx context.Context, controller string, c client.Client, objs []Object, concurrency int) error {
+	waves, byWave := sortedWaves(objs)
+
+	for i := len(waves) - 1; i >= 0; i-- {
+		if err := deleteBatch(ctx, c, byWave[waves[i]], concurrency); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func applyBatch(ctx context.Context, controller string, c client.Client, objs []Object, concurrency int) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	sem := make(chan struct{}, concurrency)
+	errs := make([]error, len(objs))
+
+	var wg sync.WaitGroup
+	for i, o := range objs {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, o Object) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if _, err := CreateOrPatch(ctx, controller, c, o.Obj, o.Mutate); err != nil {
+				errs[i] = fmt.Errorf("%s/%s: %w", o.Obj.GetNamespace(), o.Obj.GetName(), err)
+			}
+		}(i, o)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+func deleteBatch(ctx context.Context, c client.Client, objs []Object, concurrency int) error {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	sem := make(chan struct{}, concurrency)
+	errs := make([]error, len(objs))
+
+	var wg sync.WaitGroup
+	for i, o := range objs {
+		wg.Add(1)
+		sem <- struct{}{}
+
+		go func(i int, o Object) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			if err := client.IgnoreNotFound(c.Delete(ctx, o.Obj)); err != nil {
+				errs[i] = fmt.Errorf("%s/%s: %w", o.Obj.GetNamespace(), o.Obj.GetName(), err)
+			}
+		}(i, o)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,171 @@
+// Package resourceapply wraps controller-runtime's CreateOrPatch with
+// retries on resource-version conflicts, since several controllers in this
+// operator apply to the same resources (e.g. the ClusterOperator) and would
+// otherwise surface transient conflicts as reconcile errors.
+//
+// ApplyServerSide offers a second, opt-in apply mode for controllers that
+// overlay resources a GitOps tool also owns (see pkg/gc.FieldManager):
+// rather than client-side merging the whole object, it lets the API server
+// track exactly which fields "cluster-capi-operator" owns, so the GitOps
+// tool's own apply of the fields it owns never gets overwritten or flagged
+// as drift.
+package resourceapply
+
+import (
+	"context"
+	"strings"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/openshift/cluster-capi-operator/pkg/gc"
+)
+
+var conflictsTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "resource_apply_conflicts_total",
+		Help: "Total number of resource-version conflicts retried during CreateOrPatch.",
+	},
+	[]string{"controller"},
+)
+
+var recreatesTotal = prometheus.NewCounterVec(
+	prometheus.CounterOpts{
+		Name: "resource_apply_immutable_recreates_total",
+		Help: "Total number of delete-and-recreate cycles performed by CreateOrRecreate after an immutable-field conflict.",
+	},
+	[]string{"controller"},
+)
+
+func init() {
+	metrics.Registry.MustRegister(conflictsTotal)
+	metrics.Registry.MustRegister(recreatesTotal)
+}
+
+// CreateOrPatch behaves like controllerutil.CreateOrPatch but retries on
+// conflict using the default API-server-friendly backoff, recording a
+// metric for each retried conflict so persistent contention is visible.
+func CreateOrPatch(ctx context.Context, controller string, c client.Client, obj client.Object, mutate controllerutil.MutateFn) (controllerutil.OperationResult, error) {
+	var result controllerutil.OperationResult
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		var err error
+		result, err = controllerutil.CreateOrPatch(ctx, c, obj, func() error {
+			if err := mutate(); err != nil {
+				return err
+			}
+			gc.StampIdentity(obj, "")
+			return nil
+		})
+		if apierrors.IsConflict(err) {
+			conflictsTotal.WithLabelValues(controller).Inc()
+		}
+		return err
+	})
+
+	return result, err
+}
+
+// ApplyServerSide applies obj via server-side apply under gc.FieldManager
+// instead of client-side CreateOrPatch, after running mutate and stamping
+// identity exactly as CreateOrPatch does. Unlike CreateOrPatch, obj must
+// have its TypeMeta (apiVersion/kind) set explicitly, since server-side
+// apply marshals obj as the patch body rather than relying on the scheme
+// to infer its GVK from a Get.
+//
+// Use this for resources a GitOps tool also applies manifests for: two
+// field managers each owning disjoint fields on the same object converge
+// without either one's apply ever being reported as a conflict or a diff,
+// which a shared client-side writer cannot offer.
+func ApplyServerSide(ctx context.Context, controller string, c client.Client, obj client.Object, mutate controllerutil.MutateFn) error {
+	if err := mutate(); err != nil {
+		return err
+	}
+	gc.StampIdentity(obj, "")
+
+	err := c.Patch(ctx, obj, client.Apply, client.FieldOwner(gc.FieldManager), client.ForceOwnership)
+	if apierrors.IsConflict(err) {
+		conflictsTotal.WithLabelValues(controller).Inc()
+	}
+	return err
+}
+
+// CreateOrRecreate behaves like CreateOrPatch, but if mutate changes a field
+// the API server considers immutable (e.g. Secret.Type, Service.Spec.ClusterIP),
+// the resulting error is handled by deleting and recreating obj instead of
+// being surfaced to the caller, since CreateOrPatch's Update/Patch path can
+// never succeed in that case. Any other validation failure (a bad value
+// from a caller's mutate, an invalid provider spec, a webhook rejection) is
+// returned as an ordinary error instead, since deleting obj would not fix
+// it and could turn a safely-retried reconcile error into an outage.
+// recorder, if non-nil, receives a warning event on obj recording why it
+// was recreated.
+func CreateOrRecreate(ctx context.Context, controller string, c client.Client, recorder record.EventRecorder, obj client.Object, mutate controllerutil.MutateFn) (controllerutil.OperationResult, error) {
+	result, err := CreateOrPatch(ctx, controller, c, obj, mutate)
+	if err == nil {
+		return result, nil
+	}
+	if !isImmutableFieldConflict(err) {
+		return result, err
+	}
+
+	recreatesTotal.WithLabelValues(controller).Inc()
+	if recorder != nil {
+		recorder.Eventf(obj, corev1.EventTypeWarning, "ImmutableFieldRecreate",
+			"recreating %s/%s after an immutable-field conflict: %v", obj.GetNamespace(), obj.GetName(), err)
+	}
+
+	if delErr := client.IgnoreNotFound(c.Delete(ctx, obj)); delErr != nil {
+		return result, delErr
+	}
+
+	obj.SetResourceVersion("")
+	obj.SetUID("")
+
+	createErr := retry.OnError(retry.DefaultBackoff, apierrors.IsAlreadyExists, func() error {
+		return c.Create(ctx, obj)
+	})
+	if createErr != nil {
+		return result, createErr
+	}
+
+	return controllerutil.OperationResultCreated, nil
+}
+
+// isImmutableFieldConflict reports whether err is specifically the API
+// server rejecting a change to a field it treats as immutable (e.g.
+// Secret.Type, Service.Spec.ClusterIP), as opposed to some other validation
+// failure that also surfaces as apierrors.IsInvalid (a bad value produced by
+// a caller's mutate, an invalid provider spec, a webhook rejection). Those
+// other failures must not trigger CreateOrRecreate's delete-and-recreate:
+// the recreate would fail the same validation, since the mutation that
+// produced the invalid value did not change, leaving the object deleted
+// until the underlying bug is fixed. The API server reports an
+// immutable-field rejection as a StatusReasonInvalid cause whose message
+// contains "immutable", which is the only reliable signal available without
+// hardcoding a list of known-immutable fields.
+func isImmutableFieldConflict(err error) bool {
+	statusErr, ok := err.(*apierrors.StatusError)
+	if !ok {
+		return false
+	}
+
+	if statusErr.ErrStatus.Reason != metav1.StatusReasonInvalid || statusErr.ErrStatus.Details == nil {
+		return false
+	}
+
+	for _, cause := range statusErr.ErrStatus.Details.Causes {
+		if strings.Contains(strings.ToLower(cause.Message), "immutable") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,79 @@
+package resourceapply
+
+import (
+	"sort"
+	"strconv"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// WaveAnnotation overrides an object's apply wave, for the rare manifest
+// that must apply earlier or later than its Kind's default wave (e.g. a
+// CR that depends on another CR rather than just its CRD).
+const WaveAnnotation = "capi.openshift.io/apply-wave"
+
+// Wave numbers mirror a typical install's dependency order: a namespace
+// must exist before anything in it, a CRD before any CR of that type, and
+// RBAC before the workloads that rely on it.
+const (
+	waveNamespace  = 0
+	waveCRD        = 1
+	waveRBAC       = 2
+	waveDeployment = 3
+	waveDefault    = 4
+)
+
+// namespaceKind and the RBAC/workload kinds below are checked by name
+// rather than by importing their API packages, so this package stays
+// free of those dependencies.
+const namespaceKind = "Namespace"
+
+var rbacKinds = map[string]bool{
+	"ServiceAccount":     true,
+	"Role":               true,
+	"ClusterRole":        true,
+	"RoleBinding":        true,
+	"ClusterRoleBinding": true,
+}
+
+// waveOf returns obj's apply wave: the value of WaveAnnotation if set and
+// valid, otherwise the default wave for obj's Kind.
+func waveOf(obj client.Object) int {
+	if raw, ok := obj.GetAnnotations()[WaveAnnotation]; ok {
+		if wave, err := strconv.Atoi(raw); err == nil {
+			return wave
+		}
+	}
+
+	switch kind := obj.GetObjectKind().GroupVersionKind().Kind; {
+	case kind == namespaceKind:
+		return waveNamespace
+	case kind == customResourceDefinitionKind:
+		return waveCRD
+	case rbacKinds[kind]:
+		return waveRBAC
+	case kind == "Deployment":
+		return waveDeployment
+	default:
+		return waveDefault
+	}
+}
+
+// sortedWaves groups objs by waveOf and returns their wave numbers in
+// ascending order, for an install (namespaces, then CRDs, then RBAC, then
+// workloads, then CRs).
+func sortedWaves(objs []Object) ([]int, map[int][]Object) {
+	byWave := map[int][]Object{}
+	for _, o := range objs {
+		wave := waveOf(o.Obj)
+		byWave[wave] = append(byWave[wave], o)
+	}
+
+	waves := make([]int, 0, len(byWave))
+	for wave := range byWave {
+		waves = append(waves, wave)
+	}
+	sort.Ints(waves)
+
+	return waves, byWave
+}
@@ -0,0 +1,70 @@
+// Package satoken looks up a ServiceAccount's token Secret directly by
+// index instead of iterating ServiceAccount.Secrets, which Kubernetes
+// stopped populating for auto-created token Secrets as of 1.24: a
+// ServiceAccount with an empty Secrets list can still have a perfectly
+// valid token Secret sitting alongside it, and iterating Secrets alone
+// would miss it.
+package satoken
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// serviceAccountNameAnnotation is the annotation Kubernetes stamps on
+// every Secret of Type ServiceAccountToken, naming the ServiceAccount it
+// was minted for.
+const serviceAccountNameAnnotation = "kubernetes.io/service-account.name"
+
+// indexField is the field indexer name registered by IndexByServiceAccount
+// and consulted by Lookup.
+const indexField = "satoken-service-account-name"
+
+// IndexByServiceAccount registers a field indexer on Secret keyed by the
+// ServiceAccount name its serviceAccountNameAnnotation names, restricted
+// to Secrets of Type ServiceAccountToken. Call it once during manager
+// setup, before starting the manager, so Lookup can resolve a
+// ServiceAccount's token Secret with a single indexed List instead of
+// scanning every Secret in the namespace.
+func IndexByServiceAccount(mgr ctrl.Manager) error {
+	return mgr.GetFieldIndexer().IndexField(context.Background(), &corev1.Secret{}, indexField, func(obj client.Object) []string {
+		secret, ok := obj.(*corev1.Secret)
+		if !ok || secret.Type != corev1.SecretTypeServiceAccountToken {
+			return nil
+		}
+		name := secret.Annotations[serviceAccountNameAnnotation]
+		if name == "" {
+			return nil
+		}
+		return []string{name}
+	})
+}
+
+// Lookup returns the token Secret for the ServiceAccount named
+// serviceAccountName in namespace, using the index registered by
+// IndexByServiceAccount. If more than one matching token Secret exists
+// (e.g. during a token rotation overlap), the most recently created one
+// is returned.
+func Lookup(ctx context.Context, c client.Client, namespace, serviceAccountName string) (*corev1.Secret, error) {
+	secrets := &corev1.SecretList{}
+	if err := c.List(ctx, secrets, client.InNamespace(namespace), client.MatchingFields{indexField: serviceAccountName}); err != nil {
+		return nil, fmt.Errorf("satoken: failed to list token secrets for service account %s/%s: %w", namespace, serviceAccountName, err)
+	}
+
+	if len(secrets.Items) == 0 {
+		return nil, fmt.Errorf("satoken: no token secret found for service account %s/%s", namespace, serviceAccountName)
+	}
+
+	newest := &secrets.Items[0]
+	for i := range secrets.Items[1:] {
+		candidate := &secrets.Items[i+1]
+		if candidate.CreationTimestamp.After(newest.CreationTimestamp.Time) {
+			newest = candidate
+		}
+	}
+	return newest, nil
+}
@@ -0,0 +1,74 @@
+// Package secretsync replicates a secret from one namespace into others,
+// stamping each copy with an ownership annotation back to the source so
+// that replicas can be identified and garbage collected if the source is
+// deleted.
+package secretsync
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+)
+
+// SourceAnnotation records the namespace/name of the secret a replica was
+// copied from, e.g. "openshift-cluster-api/capi-kubeconfig".
+const SourceAnnotation = "cluster-api.openshift.io/replicated-from"
+
+// Replicate copies source into each of destinationNamespaces, creating or
+// updating a same-named secret there and recording SourceAnnotation on each
+// copy.
+func Replicate(ctx context.Context, c client.Client, source *corev1.Secret, destinationNamespaces []string) error {
+	sourceRef := fmt.Sprintf("%s/%s", source.Namespace, source.Name)
+
+	for _, ns := range destinationNamespaces {
+		replica := &corev1.Secret{}
+		replica.Name = source.Name
+		replica.Namespace = ns
+
+		_, err := controllerutil.CreateOrPatch(ctx, c, replica, func() error {
+			if replica.Annotations == nil {
+				replica.Annotations = map[string]string{}
+			}
+			replica.Annotations[SourceAnnotation] = sourceRef
+			replica.Type = source.Type
+			replica.Data = copySecretData(source.Data)
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to replicate secret %s/%s to namespace %s: %w", source.Namespace, source.Name, ns, err)
+		}
+	}
+
+	return nil
+}
+
+func copySecretData(in map[string][]byte) map[string][]byte {
+	out := make(map[string][]byte, len(in))
+	for k, v := range in {
+		out[k] = append([]byte(nil), v...)
+	}
+	return out
+}
+
+// ListReplicas returns all secrets across the cluster that were replicated
+// from source, identified by SourceAnnotation.
+func ListReplicas(ctx context.Context, c client.Client, source types.NamespacedName) ([]corev1.Secret, error) {
+	all := &corev1.SecretList{}
+	if err := c.List(ctx, all); err != nil {
+		return nil, err
+	}
+
+	sourceRef := fmt.Sprintf("%s/%s", source.Namespace, source.Name)
+	var replicas []corev1.Secret
+	for _, s := range all.Items {
+		if s.Annotations[SourceAnnotation] == sourceRef {
+			replicas = append(replicas, s)
+		}
+	}
+
+	return replicas, nil
+}
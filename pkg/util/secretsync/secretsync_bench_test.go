@@ -0,0 +1,68 @@
+package secretsync
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// BenchmarkReplicate measures apply latency for fanning a single secret out
+// to a scale-representative number of destination namespaces (modelling the
+// "1000 secrets, 100 clusters" scenario this operator must tolerate).
+func BenchmarkReplicate(b *testing.B) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		b.Fatalf("failed to build scheme: %v", err)
+	}
+
+	source := &corev1.Secret{}
+	source.Name = "capi-kubeconfig"
+	source.Namespace = "openshift-cluster-api"
+	source.Data = map[string][]byte{"kubeconfig": []byte("fake-data")}
+
+	destinations := make([]string, 100)
+	for i := range destinations {
+		destinations[i] = fmt.Sprintf("cluster-%d", i)
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		if err := Replicate(context.Background(), c, source, destinations); err != nil {
+			b.Fatalf("Replicate failed: %v", err)
+		}
+	}
+}
+
+// BenchmarkListReplicas measures the cost of filtering replicas out of a
+// cluster-wide secret listing as the number of unrelated secrets grows.
+func BenchmarkListReplicas(b *testing.B) {
+	scheme := runtime.NewScheme()
+	if err := corev1.AddToScheme(scheme); err != nil {
+		b.Fatalf("failed to build scheme: %v", err)
+	}
+
+	builder := fake.NewClientBuilder().WithScheme(scheme)
+	for i := 0; i < 1000; i++ {
+		s := &corev1.Secret{}
+		s.Name = fmt.Sprintf("secret-%d", i)
+		s.Namespace = fmt.Sprintf("cluster-%d", i%100)
+		if i%10 == 0 {
+			s.Annotations = map[string]string{SourceAnnotation: "openshift-cluster-api/capi-kubeconfig"}
+		}
+		builder = builder.WithObjects(s)
+	}
+	c := builder.Build()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := ListReplicas(context.Background(), c, types.NamespacedName{Namespace: "openshift-cluster-api", Name: "capi-kubeconfig"}); err != nil {
+			b.Fatalf("ListReplicas failed: %v", err)
+		}
+	}
+}
@@ -0,0 +1,96 @@
+package testutil
+
+import (
+	"context"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// FakeClientOption configures the interceptor.Funcs used by NewFakeClient,
+// letting controller unit tests inject failure modes without standing up
+// envtest.
+type FakeClientOption func(*interceptor.Funcs)
+
+// WithGetError fails every Get call for the given GroupKind with err, to
+// exercise a reconciler's error-handling path for a failed read.
+func WithGetError(gk schema.GroupKind, err error) FakeClientOption {
+	return func(f *interceptor.Funcs) {
+		f.Get = func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+			if obj.GetObjectKind().GroupVersionKind().GroupKind() == gk {
+				return err
+			}
+			return c.Get(ctx, key, obj, opts...)
+		}
+	}
+}
+
+// WithPatchError fails every Patch call with err, to exercise a
+// reconciler's error-handling path for a failed apply.
+func WithPatchError(err error) FakeClientOption {
+	return func(f *interceptor.Funcs) {
+		f.Patch = func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			return err
+		}
+	}
+}
+
+// WithConflictOnUpdate fails the first n Update calls with a Conflict
+// error, to exercise a reconciler's retry-on-conflict behavior.
+func WithConflictOnUpdate(n int) FakeClientOption {
+	remaining := n
+	return func(f *interceptor.Funcs) {
+		f.Update = func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			if remaining > 0 {
+				remaining--
+				return apierrors.NewConflict(schema.GroupResource{Resource: obj.GetObjectKind().GroupVersionKind().Kind}, obj.GetName(), nil)
+			}
+			return c.Update(ctx, obj, opts...)
+		}
+	}
+}
+
+// WithLatency delays every Get call through the client by d, to exercise
+// reconcile timeout handling under a slow API server. Other calls are not
+// delayed.
+func WithLatency(d time.Duration) FakeClientOption {
+	return func(f *interceptor.Funcs) {
+		f.Get = wrapGetLatency(f.Get, d)
+	}
+}
+
+func wrapGetLatency(next func(context.Context, client.WithWatch, client.ObjectKey, client.Object, ...client.GetOption) error, d time.Duration) func(context.Context, client.WithWatch, client.ObjectKey, client.Object, ...client.GetOption) error {
+	return func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+		select {
+		case <-time.After(d):
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		if next != nil {
+			return next(ctx, c, key, obj, opts...)
+		}
+		return c.Get(ctx, key, obj, opts...)
+	}
+}
+
+// NewFakeClient builds a fake controller-runtime client seeded with objs,
+// with the given options applied as interceptor hooks so tests can cover
+// error paths (failed gets/patches, update conflicts, slow responses)
+// without a live API server.
+func NewFakeClient(scheme *runtime.Scheme, objs []client.Object, opts ...FakeClientOption) client.Client {
+	var funcs interceptor.Funcs
+	for _, opt := range opts {
+		opt(&funcs)
+	}
+
+	return fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objs...).
+		WithInterceptorFuncs(funcs).
+		Build()
+}
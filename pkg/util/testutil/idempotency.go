@@ -0,0 +1,75 @@
+// Package testutil provides test helpers shared by this operator's
+// controller unit tests, such as asserting that a reconciler is idempotent.
+package testutil
+
+import (
+	"context"
+	"testing"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+// WriteCounter tallies Create/Update/Patch/Delete calls observed through an
+// interceptor.Funcs built by NewCountingInterceptor, so tests can assert on
+// how many writes a reconcile pass actually performed.
+type WriteCounter struct {
+	count int
+}
+
+// Count returns the number of writes observed since the counter was last
+// reset.
+func (w *WriteCounter) Count() int {
+	return w.count
+}
+
+// Reset zeroes the counter, typically called between reconcile passes.
+func (w *WriteCounter) Reset() {
+	w.count = 0
+}
+
+// NewCountingInterceptor returns interceptor.Funcs that increment counter on
+// every Create, Update, Patch and Delete call, for use with
+// fake.NewClientBuilder().WithInterceptorFuncs.
+func NewCountingInterceptor(counter *WriteCounter) interceptor.Funcs {
+	return interceptor.Funcs{
+		Create: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.CreateOption) error {
+			counter.count++
+			return c.Create(ctx, obj, opts...)
+		},
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			counter.count++
+			return c.Update(ctx, obj, opts...)
+		},
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			counter.count++
+			return c.Patch(ctx, obj, patch, opts...)
+		},
+		Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+			counter.count++
+			return c.Delete(ctx, obj, opts...)
+		},
+	}
+}
+
+// AssertIdempotent runs reconcile twice against the same counter-wrapped
+// client and fails t if the second pass performs any writes at all, on the
+// assumption that a correctly written reconciler converges to a steady
+// state and should not keep re-writing the same objects every pass.
+func AssertIdempotent(t *testing.T, counter *WriteCounter, reconcile func() error) {
+	t.Helper()
+
+	if err := reconcile(); err != nil {
+		t.Fatalf("first reconcile pass failed: %v", err)
+	}
+
+	counter.Reset()
+
+	if err := reconcile(); err != nil {
+		t.Fatalf("second reconcile pass failed: %v", err)
+	}
+
+	if got := counter.Count(); got != 0 {
+		t.Errorf("expected zero writes on second reconcile pass, got %d", got)
+	}
+}
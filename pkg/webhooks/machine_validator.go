@@ -0,0 +1,112 @@
+// Package webhooks contains admission webhooks that guard Cluster API
+// objects this operator is not yet ready to let users manage freely.
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// controlPlaneLabel marks a CAPI Machine as belonging to the control
+// plane.
+const controlPlaneLabel = "cluster.x-k8s.io/control-plane"
+
+// controlPlaneRefMarker is the substring checked for, case-insensitively,
+// in a Machine's infrastructure and bootstrap config reference Kind and
+// Name, to catch control-plane Machines that a control-plane provider
+// created without (yet) carrying controlPlaneLabel.
+const controlPlaneRefMarker = "controlplane"
+
+// MachineValidator rejects CAPI Machines that would manage a control-plane
+// node, either by carrying controlPlaneLabel directly or by referencing a
+// control-plane infrastructure or bootstrap template, since this operator
+// only supports worker-only CAPI management until control-plane
+// integration (see the control-plane machine set integration work)
+// lands.
+type MachineValidator struct {
+	// ControlPlaneEnabled, when true, allows control-plane Machines through
+	// unchanged. It exists as an explicit opt-in escape hatch for the
+	// control-plane support phase, rather than a platform-wide default.
+	ControlPlaneEnabled bool
+}
+
+var _ admission.CustomValidator = &MachineValidator{}
+
+// SetupWebhookWithManager registers the validating webhook with the
+// Manager.
+func (v *MachineValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&clusterv1.Machine{}).
+		WithValidator(v).
+		Complete()
+}
+
+// ValidateCreate rejects a control-plane Machine unless ControlPlaneEnabled
+// is set.
+func (v *MachineValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validate(obj)
+}
+
+// ValidateUpdate rejects turning a worker Machine into a control-plane one
+// unless ControlPlaneEnabled is set.
+func (v *MachineValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	return nil, v.validate(newObj)
+}
+
+// ValidateDelete allows every deletion; this guard only blocks CAPI from
+// taking on control-plane management, not from relinquishing it.
+func (v *MachineValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (v *MachineValidator) validate(obj runtime.Object) error {
+	if v.ControlPlaneEnabled {
+		return nil
+	}
+
+	machine, ok := obj.(*clusterv1.Machine)
+	if !ok {
+		return nil
+	}
+
+	if _, isControlPlane := machine.Labels[controlPlaneLabel]; isControlPlane {
+		return fmt.Errorf("Cluster API control-plane Machine management is not yet supported on OpenShift; Machine %s/%s carries the %s label", machine.Namespace, machine.Name, controlPlaneLabel)
+	}
+
+	if ref, isControlPlane := controlPlaneTemplateRef(machine); isControlPlane {
+		return fmt.Errorf("Cluster API control-plane Machine management is not yet supported on OpenShift; Machine %s/%s references control-plane template %s %s", machine.Namespace, machine.Name, ref.Kind, ref.Name)
+	}
+
+	return nil
+}
+
+// controlPlaneTemplateRef reports the first of machine's infrastructure or
+// bootstrap config references whose Kind or Name marks it as a
+// control-plane template (see controlPlaneRefMarker), so a control-plane
+// Machine that a provider created without controlPlaneLabel is still
+// caught.
+func controlPlaneTemplateRef(machine *clusterv1.Machine) (corev1.ObjectReference, bool) {
+	if refLooksControlPlane(machine.Spec.InfrastructureRef) {
+		return machine.Spec.InfrastructureRef, true
+	}
+
+	if machine.Spec.Bootstrap.ConfigRef != nil && refLooksControlPlane(*machine.Spec.Bootstrap.ConfigRef) {
+		return *machine.Spec.Bootstrap.ConfigRef, true
+	}
+
+	return corev1.ObjectReference{}, false
+}
+
+// refLooksControlPlane reports whether ref's Kind or Name contains
+// controlPlaneRefMarker, case-insensitively.
+func refLooksControlPlane(ref corev1.ObjectReference) bool {
+	lower := strings.ToLower(ref.Kind + ref.Name)
+	return strings.Contains(lower, controlPlaneRefMarker)
+}
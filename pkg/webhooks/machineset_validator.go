@@ -0,0 +1,93 @@
+package webhooks
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/openshift/cluster-capi-operator/pkg/conversion"
+)
+
+// MachineSetValidator rejects Spec writes to a CAPI MachineSet while
+// machine-api is authoritative for it (see
+// pkg/conversion.AuthoritativeAPIFor), since that MachineSet is a
+// generated, read-only mirror until its authority is switched. Writes
+// from OperatorServiceAccount, the identity the machinesetsync controller
+// itself runs as, are exempted so it can keep the mirror up to date.
+type MachineSetValidator struct {
+	// OperatorServiceAccount is the fully-qualified username (e.g.
+	// "system:serviceaccount:openshift-cluster-api:cluster-capi-operator")
+	// the machinesetsync controller authenticates as. Writes from any
+	// other identity to a non-authoritative MachineSet are rejected.
+	OperatorServiceAccount string
+}
+
+var _ admission.CustomValidator = &MachineSetValidator{}
+
+// SetupWebhookWithManager registers the validating webhook with the
+// Manager.
+func (v *MachineSetValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&clusterv1.MachineSet{}).
+		WithValidator(v).
+		Complete()
+}
+
+// ValidateCreate allows every creation; the machinesetsync controller is
+// the only caller expected to create a mirrored MachineSet, and a bare
+// create has no prior Spec to diverge from.
+func (v *MachineSetValidator) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// ValidateUpdate rejects a Spec change to machineSet when it is not
+// authoritative for its own Spec, unless the request comes from
+// OperatorServiceAccount.
+func (v *MachineSetValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+	oldMachineSet, ok := oldObj.(*clusterv1.MachineSet)
+	if !ok {
+		return nil, nil
+	}
+	newMachineSet, ok := newObj.(*clusterv1.MachineSet)
+	if !ok {
+		return nil, nil
+	}
+
+	if reflect.DeepEqual(oldMachineSet.Spec, newMachineSet.Spec) {
+		return nil, nil
+	}
+
+	if conversion.AuthoritativeAPIFor(newMachineSet.Annotations) == conversion.AuthoritativeAPIClusterAPI {
+		return nil, nil
+	}
+
+	if v.requestFromOperator(ctx) {
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("MachineSet %s/%s is a mirror of a machine-api MachineSet; set the %s annotation to %q before editing its spec directly", newMachineSet.Namespace, newMachineSet.Name, conversion.AuthoritativeAPIAnnotation, conversion.AuthoritativeAPIClusterAPI)
+}
+
+// ValidateDelete allows every deletion; this guard only blocks direct
+// edits to a mirrored MachineSet's Spec, not its removal.
+func (v *MachineSetValidator) ValidateDelete(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, nil
+}
+
+// requestFromOperator reports whether the in-flight admission request, if
+// any, was made by OperatorServiceAccount.
+func (v *MachineSetValidator) requestFromOperator(ctx context.Context) bool {
+	if v.OperatorServiceAccount == "" {
+		return false
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return false
+	}
+	return req.UserInfo.Username == v.OperatorServiceAccount
+}
@@ -0,0 +1,140 @@
+//go:build e2e
+
+// Package e2e holds acceptance tests that run against a live cluster with
+// the operator installed, gated behind the "e2e" build tag so `go test
+// ./...` never attempts them without a real cluster and `go test -tags e2e
+// ./test/e2e/...` is required to opt in.
+package e2e
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	configv1 "github.com/openshift/api/config/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// machineReadyTimeout bounds how long a test waits for a new Machine to
+// report a joined Node, and how long it waits for a scaled-down Machine
+// to finish deprovisioning.
+const machineReadyTimeout = 20 * time.Minute
+
+// platforms lists the PlatformTypes this suite exercises. Each is skipped
+// at runtime unless the live cluster's Infrastructure object reports that
+// platform, so the same binary serves as the acceptance gate across CI
+// lanes without a separate test per platform.
+var platforms = []configv1.PlatformType{
+	configv1.AWSPlatformType,
+	configv1.GCPPlatformType,
+	configv1.AzurePlatformType,
+}
+
+// TestMachineLifecycle creates a one-replica CAPI MachineSet on the
+// cluster's detected platform, waits for its Machine to join as a Node,
+// scales it to zero, and verifies the Machine is cleaned up —
+// the operator's acceptance gate for each supported platform.
+func TestMachineLifecycle(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := client.New(ctrl.GetConfigOrDie(), client.Options{})
+	if err != nil {
+		t.Fatalf("failed to build client: %v", err)
+	}
+
+	infra := &configv1.Infrastructure{}
+	if err := c.Get(ctx, types.NamespacedName{Name: "cluster"}, infra); err != nil {
+		t.Fatalf("failed to get cluster Infrastructure: %v", err)
+	}
+
+	platform := infra.Status.PlatformStatus.Type
+	if !platformSupported(platform) {
+		t.Skipf("platform %s is not covered by this suite", platform)
+	}
+
+	machineSet := &clusterv1.MachineSet{}
+	machineSet.GenerateName = "e2e-lifecycle-"
+	machineSet.Namespace = "openshift-cluster-api"
+	replicas := int32(1)
+	machineSet.Spec.Replicas = &replicas
+	machineSet.Spec.ClusterName = infra.Status.InfrastructureName
+
+	if err := c.Create(ctx, machineSet); err != nil {
+		t.Fatalf("failed to create MachineSet: %v", err)
+	}
+	t.Cleanup(func() {
+		if err := client.IgnoreNotFound(c.Delete(ctx, machineSet)); err != nil {
+			t.Errorf("failed to clean up MachineSet: %v", err)
+		}
+	})
+
+	machine, err := waitForMachine(ctx, c, machineSet)
+	if err != nil {
+		t.Fatalf("machine did not join as a Node: %v", err)
+	}
+	t.Logf("machine %s joined as node %s", machine.Name, nodeRefName(machine))
+
+	replicas = 0
+	if err := c.Get(ctx, client.ObjectKeyFromObject(machineSet), machineSet); err != nil {
+		t.Fatalf("failed to refresh MachineSet before scale-down: %v", err)
+	}
+	machineSet.Spec.Replicas = &replicas
+	if err := c.Update(ctx, machineSet); err != nil {
+		t.Fatalf("failed to scale MachineSet to zero: %v", err)
+	}
+
+	if err := waitForMachineDeleted(ctx, c, machine); err != nil {
+		t.Fatalf("machine was not cleaned up after scale-down: %v", err)
+	}
+}
+
+func platformSupported(platform configv1.PlatformType) bool {
+	for _, p := range platforms {
+		if p == platform {
+			return true
+		}
+	}
+	return false
+}
+
+func waitForMachine(ctx context.Context, c client.Client, machineSet *clusterv1.MachineSet) (*clusterv1.Machine, error) {
+	var found *clusterv1.Machine
+
+	err := wait.PollUntilContextTimeout(ctx, 10*time.Second, machineReadyTimeout, true, func(ctx context.Context) (bool, error) {
+		machines := &clusterv1.MachineList{}
+		if err := c.List(ctx, machines, client.InNamespace(machineSet.Namespace), client.MatchingLabels{clusterv1.MachineSetNameLabel: machineSet.Name}); err != nil {
+			return false, err
+		}
+		for i := range machines.Items {
+			if nodeRefName(&machines.Items[i]) != "" {
+				found = &machines.Items[i]
+				return true, nil
+			}
+		}
+		return false, nil
+	})
+
+	return found, err
+}
+
+func waitForMachineDeleted(ctx context.Context, c client.Client, machine *clusterv1.Machine) error {
+	return wait.PollUntilContextTimeout(ctx, 10*time.Second, machineReadyTimeout, true, func(ctx context.Context) (bool, error) {
+		err := c.Get(ctx, client.ObjectKeyFromObject(machine), &clusterv1.Machine{})
+		if errors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+}
+
+func nodeRefName(machine *clusterv1.Machine) string {
+	if machine.Status.NodeRef == nil {
+		return ""
+	}
+	return machine.Status.NodeRef.Name
+}